@@ -0,0 +1,95 @@
+package day1
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+)
+
+// errOverflow is returned when a total does not fit in an int.
+var errOverflow = errors.New("integer overflow")
+
+// add adds two ints, failing if the sum overflows.
+func add(a, b int) (int, error) {
+	s := a + b
+	if (b > 0 && s < a) || (b < 0 && s > a) {
+		return 0, fmt.Errorf("%d + %d: %w", a, b, errOverflow)
+	}
+	return s, nil
+}
+
+// sub subtracts two ints, failing if the difference overflows.
+func sub(a, b int) (int, error) {
+	d := a - b
+	if (b > 0 && d > a) || (b < 0 && d < a) {
+		return 0, fmt.Errorf("%d - %d: %w", a, b, errOverflow)
+	}
+	return d, nil
+}
+
+// mul multiplies two ints, failing if the product overflows.
+func mul(a, b int) (int, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	p := a * b
+	if p/b != a || (a == -1 && b == math.MinInt) || (b == -1 && a == math.MinInt) {
+		return 0, fmt.Errorf("%d * %d: %w", a, b, errOverflow)
+	}
+	return p, nil
+}
+
+// absDiff finds the absolute difference of two ints, failing if it overflows.
+func absDiff(a, b int) (int, error) {
+	if a < b {
+		a, b = b, a
+	}
+	return sub(a, b)
+}
+
+// fitInt converts n to an int, failing if it does not fit.
+func fitInt(n *big.Int) (int, error) {
+	if !n.IsInt64() || int64(int(n.Int64())) != n.Int64() {
+		return 0, fmt.Errorf("%v does not fit in an int: %w", n, errOverflow)
+	}
+	return int(n.Int64()), nil
+}
+
+// getDistanceBig calculates the distance between two lists of ints like
+// getDistance, but cannot overflow.
+func getDistanceBig(ls, rs []int) (*big.Int, error) {
+	if len(ls) != len(rs) {
+		return nil, fmt.Errorf("lists should be same length; got %d and %d", len(ls), len(rs))
+	}
+	ls = slices.Sorted(slices.Values(ls))
+	rs = slices.Sorted(slices.Values(rs))
+
+	d := new(big.Int)
+	var l, r big.Int
+	for i := range ls {
+		l.SetInt64(int64(ls[i]))
+		r.SetInt64(int64(rs[i]))
+		d.Add(d, l.Sub(&l, &r).Abs(&l))
+	}
+	return d, nil
+}
+
+// getSimilarityBig calculates the similarity of two lists of ints like
+// getSimilarity, but cannot overflow.
+func getSimilarityBig(ls, rs []int) *big.Int {
+	counts := make(map[int]int64)
+	for _, r := range rs {
+		counts[r] += 1
+	}
+
+	sim := new(big.Int)
+	var l, n big.Int
+	for _, v := range ls {
+		l.SetInt64(int64(v))
+		n.SetInt64(counts[v])
+		sim.Add(sim, n.Mul(&l, &n))
+	}
+	return sim
+}
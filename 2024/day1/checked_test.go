@@ -0,0 +1,176 @@
+package day1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestCheckedArithmetic(t *testing.T) {
+	tests := []struct {
+		name     string
+		f        func(a, b int) (int, error)
+		a, b     int
+		want     int
+		overflow bool
+	}{
+		{"add", add, 2, 3, 5, false},
+		{"add max", add, math.MaxInt - 1, 1, math.MaxInt, false},
+		{"add over max", add, math.MaxInt, 1, 0, true},
+		{"add min", add, math.MinInt + 1, -1, math.MinInt, false},
+		{"add under min", add, math.MinInt, -1, 0, true},
+		{"sub", sub, 2, 3, -1, false},
+		{"sub over max", sub, 0, math.MinInt, 0, true},
+		{"sub under min", sub, math.MinInt, 1, 0, true},
+		{"mul", mul, -4, 3, -12, false},
+		{"mul zero", mul, 0, math.MinInt, 0, false},
+		{"mul max", mul, math.MaxInt, 1, math.MaxInt, false},
+		{"mul over max", mul, math.MaxInt/2 + 1, 2, 0, true},
+		{"mul min", mul, math.MinInt / 2, 2, math.MinInt, false},
+		{"mul negate min", mul, -1, math.MinInt, 0, true},
+		{"mul min negate", mul, math.MinInt, -1, 0, true},
+		{"absDiff", absDiff, 3, 10, 7, false},
+		{"absDiff max", absDiff, math.MaxInt, 0, math.MaxInt, false},
+		{"absDiff over max", absDiff, math.MinInt, 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.f(tt.a, tt.b)
+			if tt.overflow {
+				if !errors.Is(err, errOverflow) {
+					t.Errorf("%s(%d, %d) = %d, %v; want overflow", tt.name, tt.a, tt.b, got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("%s(%d, %d) = %d, %v; want %d", tt.name, tt.a, tt.b, got, err, tt.want)
+			}
+		})
+	}
+}
+
+// bigSum sums ints without overflowing.
+func bigSum(vs ...int) *big.Int {
+	s := new(big.Int)
+	for _, v := range vs {
+		s.Add(s, big.NewInt(int64(v)))
+	}
+	return s
+}
+
+func TestDistanceOverflow(t *testing.T) {
+	tests := []struct {
+		name     string
+		ls, rs   []int
+		want     *big.Int
+		overflow bool
+	}{
+		{"max", []int{math.MaxInt}, []int{0}, bigSum(math.MaxInt), false},
+		{"min", []int{math.MinInt + 1}, []int{0}, bigSum(math.MaxInt), false},
+		{"pair over max", []int{math.MinInt}, []int{0}, bigSum(math.MaxInt, 1), true},
+		{"total over max", []int{math.MaxInt, 1}, []int{0, 0}, bigSum(math.MaxInt, 1), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data bytes.Buffer
+			for i := range tt.ls {
+				fmt.Fprintf(&data, "%d %d\n", tt.ls[i], tt.rs[i])
+			}
+			for name, f := range map[string]func(ls, rs []int) (int, error){
+				"getDistance": getDistance,
+				"streamDistance": func([]int, []int) (int, error) {
+					return streamDistance(bytes.NewReader(data.Bytes()), defaultOptions.columns, streamConfig{})
+				},
+			} {
+				got, err := f(tt.ls, tt.rs)
+				switch {
+				case tt.overflow && !errors.Is(err, errOverflow):
+					t.Errorf("%s(%v, %v) = %d, %v; want overflow", name, tt.ls, tt.rs, got, err)
+				case !tt.overflow && (err != nil || big.NewInt(int64(got)).Cmp(tt.want) != 0):
+					t.Errorf("%s(%v, %v) = %d, %v; want %v", name, tt.ls, tt.rs, got, err, tt.want)
+				}
+			}
+
+			gotBig, err := getDistanceBig(tt.ls, tt.rs)
+			if err != nil || gotBig.Cmp(tt.want) != 0 {
+				t.Errorf("getDistanceBig(%v, %v) = %v, %v; want %v", tt.ls, tt.rs, gotBig, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSimilarityOverflow(t *testing.T) {
+	tests := []struct {
+		name     string
+		ls, rs   []int
+		want     *big.Int
+		overflow bool
+	}{
+		{"max", []int{math.MaxInt}, []int{math.MaxInt}, bigSum(math.MaxInt), false},
+		{"min", []int{math.MinInt}, []int{math.MinInt}, bigSum(math.MinInt), false},
+		{"score over max", []int{math.MaxInt}, []int{math.MaxInt, math.MaxInt}, bigSum(math.MaxInt, math.MaxInt), true},
+		{"total over max", []int{math.MaxInt, 1}, []int{math.MaxInt, 1}, bigSum(math.MaxInt, 1), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getSimilarity(tt.ls, tt.rs)
+			switch {
+			case tt.overflow && !errors.Is(err, errOverflow):
+				t.Errorf("getSimilarity(%v, %v) = %d, %v; want overflow", tt.ls, tt.rs, got, err)
+			case !tt.overflow && (err != nil || big.NewInt(int64(got)).Cmp(tt.want) != 0):
+				t.Errorf("getSimilarity(%v, %v) = %d, %v; want %v", tt.ls, tt.rs, got, err, tt.want)
+			}
+
+			if got := getSimilarityBig(tt.ls, tt.rs); got.Cmp(tt.want) != 0 {
+				t.Errorf("getSimilarityBig(%v, %v) = %v; want %v", tt.ls, tt.rs, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFitInt(t *testing.T) {
+	if got, err := fitInt(big.NewInt(-7)); err != nil || got != -7 {
+		t.Errorf("fitInt(-7) = %d, %v; want -7", got, err)
+	}
+	tooBig := new(big.Int).Lsh(big.NewInt(1), 64)
+	if _, err := fitInt(tooBig); !errors.Is(err, errOverflow) {
+		t.Errorf("fitInt(2^64) = %v; want overflow", err)
+	}
+}
+
+func TestSolverBig(t *testing.T) {
+	s := &solver{opts: defaultOptions}
+	if err := s.Configure([]string{"--big"}); err != nil {
+		t.Fatalf("Configure returned error: %v", err)
+	}
+
+	// The scores overflow, but cancel out.
+	l := lists{{math.MaxInt, -math.MaxInt}, {math.MaxInt, math.MaxInt, -math.MaxInt, -math.MaxInt}}
+	if _, err := (&solver{opts: defaultOptions}).Part2(l); !errors.Is(err, errOverflow) {
+		t.Errorf("Part2 without --big = %v; want overflow", err)
+	}
+	if got, err := s.Part2(l); err != nil || got != 0 {
+		t.Errorf("Part2 with --big = %d, %v; want 0", got, err)
+	}
+
+	// The distance is 2^64.
+	l = lists{{math.MaxInt, math.MaxInt}, {-1, -1}}
+	if _, err := s.Part1(l); !errors.Is(err, errOverflow) {
+		t.Errorf("Part1 with --big = %v; want overflow", err)
+	}
+	var b bytes.Buffer
+	if err := s.Explain(&b, l); err != nil {
+		t.Fatalf("Explain returned error: %v", err)
+	}
+	want := "columns 0 and 1: distance 18446744073709551616, similarity 0\n"
+	if got := b.String(); got != want {
+		t.Errorf("Explain with --big = %q; want %q", got, want)
+	}
+
+	if err := s.Configure([]string{"--big", "--metric", "squared"}); err == nil {
+		t.Errorf("Configure with --big and the squared metric returned no error")
+	}
+}
@@ -1,58 +1,69 @@
-package main
+package day1
 
 import (
+	"errors"
+	"flag"
 	"fmt"
-	"log"
-	"os"
+	"io"
 	"slices"
 	"strconv"
 	"strings"
+
+	"alger.au/aoc/input"
+	"alger.au/aoc/puzzle"
 )
 
-const separator = "   "
-const dataPath = "../data/day1.txt"
+const dataPath = "2024/data/day1.txt"
 
-// readLists reads lists of historically significant location IDs from a file.
-func readLists(path string) ([]int, []int, error) {
-	text, err := os.ReadFile(path)
-	if err != nil {
-		return nil, nil, err
-	}
+func init() {
+	puzzle.Register(2024, 1, dataPath, &solver{opts: defaultOptions})
+}
 
-	lines := strings.Split(string(text), "\n")
-	var ls, rs []int
-	for _, line := range lines {
-		if len(line) == 0 {
-			continue
+// readColumns reads columns of location IDs from data, one row per line.
+// Values may be separated by any whitespace, and every row must have as many
+// values as the first. If short is true, rows may instead leave off trailing
+// values once the columns they belong to have ended, so columns can have
+// different lengths.
+func readColumns(data []byte, short bool) ([][]int, error) {
+	width := -1
+	rows, err := input.MapNonBlank(input.Lines(data), func(line string) ([]int, error) {
+		vals, err := input.Ints(line)
+		if err != nil {
+			return nil, err
 		}
-
-		vals := strings.Split(line, separator)
-		if len(vals) != 2 {
-			return nil, nil, fmt.Errorf("expected 2 values; got %d: %v", len(vals), line)
+		if width < 0 {
+			width = len(vals)
 		}
-		l, err := strconv.Atoi(vals[0])
-		if err != nil {
-			return nil, nil, fmt.Errorf("left value: %w", err)
+		switch {
+		case short && len(vals) > width:
+			return nil, fmt.Errorf("expected at most %d values; got %d: %v", width, len(vals), line)
+		case !short && len(vals) != width:
+			return nil, fmt.Errorf("expected %d values; got %d: %v", width, len(vals), line)
 		}
-		r, err := strconv.Atoi(vals[1])
-		if err != nil {
-			return nil, nil, fmt.Errorf("right value: %w", err)
+		if short {
+			// Columns that end here stay ended.
+			width = len(vals)
 		}
-		ls = append(ls, l)
-		rs = append(rs, r)
+		return vals, nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	return ls, rs, nil
-}
 
-// abs finds the absolute value of an integer.
-func abs(i int) int {
-	if i < 0 {
-		return -i
+	cols := make([][]int, 0)
+	for _, row := range rows {
+		for i, v := range row {
+			if i == len(cols) {
+				cols = append(cols, make([]int, 0, len(rows)))
+			}
+			cols[i] = append(cols[i], v)
+		}
 	}
-	return i
+	return cols, nil
 }
 
 // getDistance calculates the distance between two lists of ints (for part 1).
+// It fails if the distance overflows; getDistanceBig does not.
 func getDistance(ls, rs []int) (int, error) {
 	if len(ls) != len(rs) {
 		return -1, fmt.Errorf("lists should be same length; got %d and %d", len(ls), len(rs))
@@ -64,16 +75,21 @@ func getDistance(ls, rs []int) (int, error) {
 
 	d := 0
 	for i := range ls {
-		l := ls[i]
-		r := rs[i]
-		d += abs(l - r)
+		diff, err := absDiff(ls[i], rs[i])
+		if err != nil {
+			return -1, err
+		}
+		if d, err = add(d, diff); err != nil {
+			return -1, err
+		}
 	}
 
 	return d, nil
 }
 
 // getSimilarity calculates the similarity of two lists of ints (for part 2).
-func getSimilarity(ls, rs []int) int {
+// It fails if the similarity overflows; getSimilarityBig does not.
+func getSimilarity(ls, rs []int) (int, error) {
 	counts := make(map[int]int)
 	for _, r := range rs {
 		counts[r] += 1
@@ -81,25 +97,252 @@ func getSimilarity(ls, rs []int) int {
 
 	sim := 0
 	for _, l := range ls {
-		sim += l * counts[l]
+		score, err := mul(l, counts[l])
+		if err != nil {
+			return -1, err
+		}
+		if sim, err = add(sim, score); err != nil {
+			return -1, err
+		}
 	}
 
-	return sim
+	return sim, nil
+}
+
+// columnPair selects a left and right column to compare.
+type columnPair struct {
+	left, right int
 }
 
-func main() {
-	ls, rs, err := readLists(dataPath)
+// MarshalText encodes a pair of columns like 0,1.
+func (p columnPair) MarshalText() ([]byte, error) {
+	return fmt.Appendf(nil, "%d,%d", p.left, p.right), nil
+}
+
+// UnmarshalText decodes a pair of columns like 0,1.
+func (p *columnPair) UnmarshalText(text []byte) error {
+	l, r, ok := strings.Cut(string(text), ",")
+	if !ok {
+		return fmt.Errorf("expected two columns: %q", text)
+	}
+	left, err := strconv.Atoi(l)
 	if err != nil {
-		log.Fatalf("reading lists: %v", err)
+		return err
+	}
+	right, err := strconv.Atoi(r)
+	if err != nil {
+		return err
+	}
+	if left < 0 || right < 0 {
+		return fmt.Errorf("negative column: %q", text)
 	}
+	*p = columnPair{left, right}
+	return nil
+}
+
+// allPairs returns every pair of n columns, with the left column first.
+func allPairs(n int) []columnPair {
+	var pairs []columnPair
+	for i := range n {
+		for j := i + 1; j < n; j++ {
+			pairs = append(pairs, columnPair{i, j})
+		}
+	}
+	return pairs
+}
+
+// comparePairs compares each pair of columns with f, like getDistance or getSimilarity.
+func comparePairs(cols [][]int, pairs []columnPair, f func(ls, rs []int) (int, error)) ([]int, error) {
+	results := make([]int, 0, len(pairs))
+	for _, p := range pairs {
+		if p.left < 0 || p.left >= len(cols) || p.right < 0 || p.right >= len(cols) {
+			return nil, fmt.Errorf("no columns %d and %d; have %d columns", p.left, p.right, len(cols))
+		}
+		v, err := f(cols[p.left], cols[p.right])
+		if err != nil {
+			return nil, fmt.Errorf("columns %d and %d: %w", p.left, p.right, err)
+		}
+		results = append(results, v)
+	}
+	return results, nil
+}
+
+// lists are the historians' lists of location IDs, one per column.
+type lists [][]int
 
-	dist, err := getDistance(ls, rs)
+// solver solves day 1.
+type solver struct {
+	// opts choose which lists to compare. Configure can change them.
+	opts options
+}
+
+// Parse reads the lists. Only the earth mover's distance allows lists of
+// different lengths.
+func (s *solver) Parse(data []byte) (lists, error) {
+	cols, err := readColumns(data, s.opts.metric == EarthMovers)
 	if err != nil {
-		log.Fatalf("getting distance: %v", err)
+		return nil, fmt.Errorf("reading lists: %w", err)
+	}
+	return cols, nil
+}
+
+// pairs returns the pairs of columns to compare.
+func (s *solver) pairs(l lists) []columnPair {
+	if s.opts.allPairs {
+		return allPairs(len(l))
 	}
+	return []columnPair{s.opts.columns}
+}
 
-	sim := getSimilarity(ls, rs)
+// compare compares the chosen pairs of columns with f and adds up the results.
+func (s *solver) compare(l lists, f func(ls, rs []int) (int, error)) (int, error) {
+	if len(l) == 0 {
+		// There is no input, so nothing to compare.
+		return 0, nil
+	}
+	results, err := comparePairs(l, s.pairs(l), f)
+	if err != nil {
+		return 0, err
+	}
+	sum := 0
+	for _, v := range results {
+		if sum, err = add(sum, v); err != nil {
+			return 0, err
+		}
+	}
+	return sum, nil
+}
 
-	fmt.Printf("1: %d\n", dist)
-	fmt.Printf("2: %d\n", sim)
+// distance finds the distance between two lists using the chosen metric.
+func (s *solver) distance(ls, rs []int) (int, error) {
+	switch {
+	case s.opts.big:
+		d, err := getDistanceBig(ls, rs)
+		if err != nil {
+			return 0, err
+		}
+		return fitInt(d)
+	case s.opts.metric == Absolute:
+		return getDistance(ls, rs)
+	default:
+		return getDistanceWith(ls, rs, s.opts.metric)
+	}
+}
+
+// similarity finds the similarity of two lists.
+func (s *solver) similarity(ls, rs []int) (int, error) {
+	if s.opts.big {
+		return fitInt(getSimilarityBig(ls, rs))
+	}
+	return getSimilarity(ls, rs)
+}
+
+// Part1 finds the total distance between the lists.
+func (s *solver) Part1(l lists) (puzzle.Answer, error) {
+	dist, err := s.compare(l, s.distance)
+	if err != nil {
+		return 0, fmt.Errorf("getting distance: %w", err)
+	}
+	return puzzle.Answer(dist), nil
+}
+
+// Part2 finds the similarity score of the lists.
+func (s *solver) Part2(l lists) (puzzle.Answer, error) {
+	sim, err := s.compare(l, s.similarity)
+	if err != nil {
+		return 0, fmt.Errorf("getting similarity: %w", err)
+	}
+	return puzzle.Answer(sim), nil
+}
+
+// Explain prints the distance and similarity of each pair of lists compared,
+// and with the breakdown option how much each pair of IDs adds to the distance.
+// With the big option, they are exact however large they are.
+func (s *solver) Explain(w io.Writer, l lists) error {
+	if len(l) == 0 {
+		return nil
+	}
+	for _, p := range s.pairs(l) {
+		if p.left >= len(l) || p.right >= len(l) {
+			return fmt.Errorf("no columns %d and %d; have %d columns", p.left, p.right, len(l))
+		}
+		if _, err := fmt.Fprintf(w, "columns %d and %d: ", p.left, p.right); err != nil {
+			return err
+		}
+		if err := s.explainPair(w, l[p.left], l[p.right]); err != nil {
+			return fmt.Errorf("columns %d and %d: %w", p.left, p.right, err)
+		}
+	}
+	return nil
+}
+
+// explainPair prints the distance and similarity of two lists.
+func (s *solver) explainPair(w io.Writer, ls, rs []int) error {
+	if s.opts.big {
+		d, err := getDistanceBig(ls, rs)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "distance %v, similarity %v\n", d, getSimilarityBig(ls, rs))
+		return err
+	}
+
+	b, err := measure(ls, rs, s.opts.metric)
+	if err != nil {
+		return err
+	}
+	dist, err := b.total()
+	if err != nil {
+		return err
+	}
+	sim, err := getSimilarity(ls, rs)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "distance %s, similarity %d\n", b.format(dist), sim); err != nil {
+		return err
+	}
+	if s.opts.breakdown {
+		return writeBreakdown(w, b)
+	}
+	return nil
+}
+
+// Stream solves a part as the lists are read from r, spilling sorted runs of
+// each list to disk. It only compares two columns by absolute distance.
+func (s *solver) Stream(part int, r io.Reader) (puzzle.Answer, error) {
+	if s.opts.allPairs || s.opts.metric != Absolute || s.opts.big {
+		return 0, errors.New("streaming only compares two columns by absolute distance, without --big")
+	}
+	cfg := streamConfig{RunSize: s.opts.runSize}
+	if part == 1 {
+		dist, err := streamDistance(r, s.opts.columns, cfg)
+		if err != nil {
+			return 0, fmt.Errorf("getting distance: %w", err)
+		}
+		return puzzle.Answer(dist), nil
+	}
+	sim, err := streamSimilarity(r, s.opts.columns, cfg)
+	if err != nil {
+		return 0, fmt.Errorf("getting similarity: %w", err)
+	}
+	return puzzle.Answer(sim), nil
+}
+
+// Configure sets the columns to compare and how from command-line flags.
+func (s *solver) Configure(args []string) error {
+	o := defaultOptions
+	fs := flag.NewFlagSet("2024 day 1", flag.ContinueOnError)
+	o.registerFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+	if err := o.validate(); err != nil {
+		return err
+	}
+	s.opts = o
+	return nil
 }
@@ -0,0 +1,286 @@
+package day1
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"alger.au/aoc/input"
+	"alger.au/aoc/puzzle"
+	"alger.au/aoc/puzzle/puzzletest"
+)
+
+func TestReadColumns(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		short bool
+		want  [][]int
+	}{
+		{"example spacing", "3   4\n4   3\n", false, [][]int{{3, 4}, {4, 3}}},
+		{"tabs and spaces", "1\t2 \t 3\n4 5\t\t6\r\n", false, [][]int{{1, 4}, {2, 5}, {3, 6}}},
+		{"one column", "7\n8\n", false, [][]int{{7, 8}}},
+		{"blank line", "3   4\n\n4   3\n", false, [][]int{{3, 4}, {4, 3}}},
+		{"empty", "", false, [][]int{}},
+		{"short rows", "1 2 3\n4 5\n6\n7\n", true, [][]int{{1, 4, 6, 7}, {2, 5}, {3}}},
+		{"short allowed but unused", "3   4\n4   3\n", true, [][]int{{3, 4}, {4, 3}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readColumns([]byte(tt.data), tt.short)
+			if err != nil {
+				t.Fatalf("readColumns(%q) returned error: %v", tt.data, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("readColumns(%q) = %v; want %v", tt.data, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadColumnsRagged(t *testing.T) {
+	tests := []struct {
+		data  string
+		short bool
+		line  int
+	}{
+		{"1 2 3\n4 5\n", false, 2},
+		{"1 2\n3 4 5\n", true, 2},
+		// A column cannot start again once it has ended.
+		{"1 2\n3\n4 5\n", true, 3},
+	}
+	for _, tt := range tests {
+		_, err := readColumns([]byte(tt.data), tt.short)
+		var ie *input.Error
+		if !errors.As(err, &ie) || ie.Line != tt.line {
+			t.Errorf("readColumns(%q, %t) = %v; want error on line %d", tt.data, tt.short, err, tt.line)
+		}
+	}
+}
+
+func TestColumnPairText(t *testing.T) {
+	var p columnPair
+	if err := p.UnmarshalText([]byte("0,2")); err != nil || p != (columnPair{0, 2}) {
+		t.Errorf("UnmarshalText(0,2) = %v, %v; want {0 2}", p, err)
+	}
+	if text, _ := p.MarshalText(); string(text) != "0,2" {
+		t.Errorf("MarshalText(%v) = %q; want 0,2", p, text)
+	}
+	for _, text := range []string{"0", "0,x", "-1,2", "1,2,3"} {
+		if err := p.UnmarshalText([]byte(text)); err == nil {
+			t.Errorf("UnmarshalText(%q) returned no error", text)
+		}
+	}
+}
+
+func TestComparePairs(t *testing.T) {
+	cols := [][]int{{1, 2}, {3, 4}, {1, 2}}
+	pairs := allPairs(len(cols))
+	if want := []columnPair{{0, 1}, {0, 2}, {1, 2}}; !reflect.DeepEqual(pairs, want) {
+		t.Fatalf("allPairs(3) = %v; want %v", pairs, want)
+	}
+
+	got, err := comparePairs(cols, pairs, getDistance)
+	if err != nil {
+		t.Fatalf("comparePairs returned error: %v", err)
+	}
+	if want := []int{4, 0, 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("comparePairs(getDistance) = %v; want %v", got, want)
+	}
+
+	got, err = comparePairs(cols, []columnPair{{2, 0}}, getSimilarity)
+	if err != nil {
+		t.Fatalf("comparePairs returned error: %v", err)
+	}
+	if want := []int{3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("comparePairs(getSimilarity) = %v; want %v", got, want)
+	}
+
+	if _, err := comparePairs(cols, []columnPair{{0, 3}}, getDistance); err == nil {
+		t.Errorf("comparePairs with a missing column returned no error")
+	}
+}
+
+func TestGetDistance(t *testing.T) {
+	tests := []struct {
+		name   string
+		ls, rs []int
+		want   int
+	}{
+		{"example", []int{3, 4, 2, 1, 3, 3}, []int{4, 3, 5, 3, 9, 3}, 11},
+		{"empty", nil, nil, 0},
+		{"identical", []int{1, 2, 3}, []int{3, 2, 1}, 0},
+		{"negative", []int{-5}, []int{5}, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getDistance(tt.ls, tt.rs)
+			if err != nil {
+				t.Fatalf("getDistance(%v, %v) returned error: %v", tt.ls, tt.rs, err)
+			}
+			if got != tt.want {
+				t.Errorf("getDistance(%v, %v) = %d; want %d", tt.ls, tt.rs, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetDistanceMismatchedLengths(t *testing.T) {
+	if _, err := getDistance([]int{1, 2}, []int{1}); err == nil {
+		t.Errorf("getDistance with mismatched lengths returned no error")
+	}
+}
+
+func TestGetSimilarity(t *testing.T) {
+	tests := []struct {
+		name   string
+		ls, rs []int
+		want   int
+	}{
+		{"example", []int{3, 4, 2, 1, 3, 3}, []int{4, 3, 5, 3, 9, 3}, 31},
+		{"empty", nil, nil, 0},
+		{"no matches", []int{1, 2}, []int{3, 4}, 0},
+		{"repeated", []int{2, 2}, []int{2, 2, 2}, 12},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getSimilarity(tt.ls, tt.rs)
+			if err != nil {
+				t.Fatalf("getSimilarity(%v, %v) returned error: %v", tt.ls, tt.rs, err)
+			}
+			if got != tt.want {
+				t.Errorf("getSimilarity(%v, %v) = %d; want %d", tt.ls, tt.rs, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSolver(t *testing.T) {
+	data, err := os.ReadFile("testdata/example.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &solver{opts: defaultOptions}
+	l, err := s.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if got, err := s.Part1(l); err != nil || got != 11 {
+		t.Errorf("Part1 = %d, %v; want 11", got, err)
+	}
+	if got, err := s.Part2(l); err != nil || got != 31 {
+		t.Errorf("Part2 = %d, %v; want 31", got, err)
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	const wide = "1 3 1\n2 4 2\n"
+	tests := []struct {
+		data    string
+		args    []string
+		dist    puzzle.Answer
+		sim     puzzle.Answer
+		explain string
+	}{
+		{wide, nil, 4, 0, "columns 0 and 1: distance 4, similarity 0\n"},
+		{wide, []string{"--columns", "0,2"}, 0, 3, "columns 0 and 2: distance 0, similarity 3\n"},
+		{wide, []string{"--all-pairs"}, 8, 3, "columns 0 and 1: distance 4, similarity 0\n" +
+			"columns 0 and 2: distance 0, similarity 3\n" +
+			"columns 1 and 2: distance 4, similarity 0\n"},
+		{wide, []string{"--metric", "squared"}, 8, 0, "columns 0 and 1: distance 8, similarity 0\n"},
+		{wide, []string{"--metric", "chebyshev", "--breakdown"}, 2, 0, "columns 0 and 1: distance 2, similarity 0\n" +
+			"       left  right  weight  distance\n" +
+			"          1      3       1         2\n" +
+			"          2      4       1         2\n" +
+			"  chebyshev                        2\n"},
+		{"1 2\n3\n", []string{"--metric", "emd"}, 2, 0, "columns 0 and 1: distance 2, similarity 0\n"},
+	}
+	for _, tt := range tests {
+		s := &solver{opts: defaultOptions}
+		if err := s.Configure(tt.args); err != nil {
+			t.Fatalf("Configure(%v) returned error: %v", tt.args, err)
+		}
+		l, err := s.Parse([]byte(tt.data))
+		if err != nil {
+			t.Fatalf("Parse(%q) with %v returned error: %v", tt.data, tt.args, err)
+		}
+		if got, err := s.Part1(l); err != nil || got != tt.dist {
+			t.Errorf("Part1 with %v = %d, %v; want %d", tt.args, got, err, tt.dist)
+		}
+		if got, err := s.Part2(l); err != nil || got != tt.sim {
+			t.Errorf("Part2 with %v = %d, %v; want %d", tt.args, got, err, tt.sim)
+		}
+		var b strings.Builder
+		if err := s.Explain(&b, l); err != nil || b.String() != tt.explain {
+			t.Errorf("Explain with %v = %q, %v; want %q", tt.args, b.String(), err, tt.explain)
+		}
+	}
+
+	// Only the earth mover's distance compares lists of different lengths.
+	if _, err := (&solver{opts: defaultOptions}).Parse([]byte("1 2\n3\n")); err == nil {
+		t.Errorf("Parse of unequal columns returned no error")
+	}
+
+	l := lists{{1, 2}, {3, 4}, {1, 2}}
+	s := &solver{opts: defaultOptions}
+	if err := s.Configure([]string{"--columns", "0,3"}); err != nil {
+		t.Fatalf("Configure returned error: %v", err)
+	}
+	if _, err := s.Part1(l); err == nil {
+		t.Errorf("Part1 comparing a missing column returned no error")
+	}
+	for _, args := range [][]string{{"--columns", "0"}, {"--metric", "manhattan"}, {"--run-size", "-1"}, {"extra"}} {
+		if err := s.Configure(args); err == nil {
+			t.Errorf("Configure(%v) returned no error", args)
+		}
+	}
+}
+
+func TestSolverStream(t *testing.T) {
+	data, err := os.ReadFile("testdata/example.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &solver{opts: defaultOptions}
+	if err := s.Configure([]string{"--run-size", "2"}); err != nil {
+		t.Fatalf("Configure returned error: %v", err)
+	}
+	for part, want := range map[int]puzzle.Answer{1: 11, 2: 31} {
+		if got, err := s.Stream(part, bytes.NewReader(data)); err != nil || got != want {
+			t.Errorf("Stream(%d) = %d, %v; want %d", part, got, err, want)
+		}
+	}
+
+	// Streaming other columns matches solving in memory.
+	wide := []byte("3 4 9\n4 3 9\n1 8 2\n")
+	if err := s.Configure([]string{"--columns", "0,2", "--run-size", "1"}); err != nil {
+		t.Fatalf("Configure returned error: %v", err)
+	}
+	l, err := s.Parse(wide)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	for part, solve := range map[int]func(lists) (puzzle.Answer, error){1: s.Part1, 2: s.Part2} {
+		want, err := solve(l)
+		if err != nil {
+			t.Fatalf("Part%d: %v", part, err)
+		}
+		if got, err := s.Stream(part, bytes.NewReader(wide)); err != nil || got != want {
+			t.Errorf("Stream(%d) of columns 0,2 = %d, %v; want %d", part, got, err, want)
+		}
+	}
+
+	if err := s.Configure([]string{"--metric", "squared"}); err != nil {
+		t.Fatalf("Configure returned error: %v", err)
+	}
+	if _, err := s.Stream(1, bytes.NewReader(data)); err == nil {
+		t.Errorf("Stream with the squared metric returned no error")
+	}
+}
+
+func BenchmarkSolver(b *testing.B) {
+	puzzletest.Benchmark(b, &solver{opts: defaultOptions}, "../data/day1.txt")
+}
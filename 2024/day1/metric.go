@@ -0,0 +1,210 @@
+package day1
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"text/tabwriter"
+)
+
+// Metric is a way of measuring the distance between two lists of location IDs,
+// once they are paired up smallest to largest.
+type Metric int
+
+const (
+	// Absolute sums the absolute differences of each pair.
+	Absolute Metric = iota
+	// SquaredEuclidean sums the squared differences of each pair.
+	SquaredEuclidean
+	// Chebyshev is the largest absolute difference of any pair.
+	Chebyshev
+	// EarthMovers is the least work needed to move one list onto the other.
+	// The lists may have different lengths: each is given a total weight equal
+	// to the length of the longer list, so for equal lengths this is Absolute.
+	EarthMovers
+)
+
+var metricNames = []string{"absolute", "squared", "chebyshev", "emd"}
+
+func (m Metric) String() string {
+	if m < 0 || int(m) >= len(metricNames) {
+		return fmt.Sprintf("Metric(%d)", int(m))
+	}
+	return metricNames[m]
+}
+
+// MarshalText encodes a metric as its name.
+func (m Metric) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText decodes a metric from its name.
+func (m *Metric) UnmarshalText(text []byte) error {
+	for i, name := range metricNames {
+		if name == string(text) {
+			*m = Metric(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown metric: %q", text)
+}
+
+// pairDistance is one pair's contribution to the distance between lists.
+type pairDistance struct {
+	Left, Right int
+	// Weight is how much of each ID is paired, in parts of an ID.
+	Weight int
+	// Distance is the pair's contribution to the distance, in the same parts.
+	Distance int
+}
+
+// breakdown is how much each pair contributes to the distance between lists.
+type breakdown struct {
+	Metric Metric
+	Pairs  []pairDistance
+	// Parts is how many parts each ID is split into. It is 1 except with EarthMovers.
+	Parts int
+}
+
+// measure pairs up the lists in sorted order and measures each pair.
+func measure(ls, rs []int, m Metric) (breakdown, error) {
+	ls = slices.Sorted(slices.Values(ls))
+	rs = slices.Sorted(slices.Values(rs))
+	if m == EarthMovers {
+		return moveEarth(ls, rs)
+	}
+	if len(ls) != len(rs) {
+		return breakdown{}, fmt.Errorf("lists should be same length; got %d and %d", len(ls), len(rs))
+	}
+
+	b := breakdown{Metric: m, Pairs: make([]pairDistance, 0, len(ls)), Parts: 1}
+	for i := range ls {
+		d, err := absDiff(ls[i], rs[i])
+		if err != nil {
+			return breakdown{}, err
+		}
+		switch m {
+		case Absolute, Chebyshev:
+		case SquaredEuclidean:
+			if d, err = mul(d, d); err != nil {
+				return breakdown{}, err
+			}
+		default:
+			return breakdown{}, fmt.Errorf("unknown metric: %v", m)
+		}
+		b.Pairs = append(b.Pairs, pairDistance{ls[i], rs[i], 1, d})
+	}
+	return b, nil
+}
+
+// moveEarth finds the cheapest way to move the sorted list ls onto rs.
+// In one dimension this moves weight between IDs in sorted order.
+func moveEarth(ls, rs []int) (breakdown, error) {
+	b := breakdown{Metric: EarthMovers, Parts: 1}
+	switch {
+	case len(ls) == 0 && len(rs) == 0:
+		return b, nil
+	case len(ls) == 0 || len(rs) == 0:
+		return breakdown{}, errors.New("cannot move between an empty list and a non-empty one")
+	}
+
+	// Each list weighs as many IDs as the longer list has. Split that weight
+	// into lcm units, so every ID of either list weighs a whole number of them.
+	units, err := mul(len(ls)/gcd(len(ls), len(rs)), len(rs))
+	if err != nil {
+		return breakdown{}, err
+	}
+	b.Parts = units / max(len(ls), len(rs))
+	lw, rw := units/len(ls), units/len(rs)
+
+	i, j := 0, 0
+	lRest, rRest := lw, rw
+	for i < len(ls) && j < len(rs) {
+		w := min(lRest, rRest)
+		d, err := absDiff(ls[i], rs[j])
+		if err != nil {
+			return breakdown{}, err
+		}
+		if d, err = mul(w, d); err != nil {
+			return breakdown{}, err
+		}
+		b.Pairs = append(b.Pairs, pairDistance{ls[i], rs[j], w, d})
+		lRest -= w
+		rRest -= w
+		if lRest == 0 {
+			i++
+			lRest = lw
+		}
+		if rRest == 0 {
+			j++
+			rRest = rw
+		}
+	}
+	return b, nil
+}
+
+// gcd finds the greatest common divisor of two positive ints.
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// total combines the distances of each pair according to the metric, in parts of an ID.
+func (b breakdown) total() (int, error) {
+	t := 0
+	for _, p := range b.Pairs {
+		if b.Metric == Chebyshev {
+			t = max(t, p.Distance)
+			continue
+		}
+		var err error
+		if t, err = add(t, p.Distance); err != nil {
+			return 0, err
+		}
+	}
+	return t, nil
+}
+
+// format formats a number of parts of an ID, as a whole number if it is one.
+func (b breakdown) format(n int) string {
+	if n%b.Parts == 0 {
+		return strconv.Itoa(n / b.Parts)
+	}
+	return strconv.FormatFloat(float64(n)/float64(b.Parts), 'g', 4, 64)
+}
+
+// getDistanceWith calculates the distance between two lists of ints using a metric.
+// It fails if the distance overflows or is not a whole number.
+func getDistanceWith(ls, rs []int, m Metric) (int, error) {
+	b, err := measure(ls, rs, m)
+	if err != nil {
+		return 0, err
+	}
+	t, err := b.total()
+	if err != nil {
+		return 0, err
+	}
+	if t%b.Parts != 0 {
+		return 0, fmt.Errorf("%v distance %s is not a whole number", m, b.format(t))
+	}
+	return t / b.Parts, nil
+}
+
+// writeBreakdown writes a table of how much each pair contributes to the distance.
+func writeBreakdown(w io.Writer, b breakdown) error {
+	t, err := b.total()
+	if err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "left\tright\tweight\tdistance\t")
+	for _, p := range b.Pairs {
+		fmt.Fprintf(tw, "%d\t%d\t%s\t%s\t\n", p.Left, p.Right, b.format(p.Weight), b.format(p.Distance))
+	}
+	fmt.Fprintf(tw, "%v\t\t\t%s\t\n", b.Metric, b.format(t))
+	return tw.Flush()
+}
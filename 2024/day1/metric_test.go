@@ -0,0 +1,126 @@
+package day1
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestGetDistanceWith(t *testing.T) {
+	example := [2][]int{{3, 4, 2, 1, 3, 3}, {4, 3, 5, 3, 9, 3}}
+	tests := []struct {
+		name   string
+		ls, rs []int
+		m      Metric
+		want   int
+	}{
+		{"absolute", example[0], example[1], Absolute, 11},
+		{"squared", example[0], example[1], SquaredEuclidean, 35},
+		{"chebyshev", example[0], example[1], Chebyshev, 5},
+		{"emd equal lengths", example[0], example[1], EarthMovers, 11},
+		{"emd longer left", []int{1, 3}, []int{2}, EarthMovers, 2},
+		{"emd longer right", []int{0}, []int{0, 0, 3}, EarthMovers, 3},
+		{"emd uneven split", []int{0, 0, 6}, []int{0, 6}, EarthMovers, 3},
+		{"emd empty", nil, nil, EarthMovers, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getDistanceWith(tt.ls, tt.rs, tt.m)
+			if err != nil {
+				t.Fatalf("getDistanceWith(%v, %v, %v) returned error: %v", tt.ls, tt.rs, tt.m, err)
+			}
+			if got != tt.want {
+				t.Errorf("getDistanceWith(%v, %v, %v) = %d; want %d", tt.ls, tt.rs, tt.m, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetDistanceWithMismatchedLengths(t *testing.T) {
+	for _, m := range []Metric{Absolute, SquaredEuclidean, Chebyshev} {
+		if _, err := getDistanceWith([]int{1, 2}, []int{1}, m); err == nil {
+			t.Errorf("getDistanceWith with mismatched lengths and %v returned no error", m)
+		}
+	}
+	if _, err := getDistanceWith([]int{1}, nil, EarthMovers); err == nil {
+		t.Errorf("getDistanceWith from an empty list with %v returned no error", EarthMovers)
+	}
+}
+
+func TestGetDistanceWithOverflow(t *testing.T) {
+	for _, m := range []Metric{Absolute, SquaredEuclidean, Chebyshev, EarthMovers} {
+		if _, err := getDistanceWith([]int{math.MinInt}, []int{1}, m); !errors.Is(err, errOverflow) {
+			t.Errorf("getDistanceWith(MinInt, 1, %v) = %v; want overflow", m, err)
+		}
+	}
+	if _, err := getDistanceWith([]int{0}, []int{1 << 32}, SquaredEuclidean); !errors.Is(err, errOverflow) {
+		t.Errorf("getDistanceWith squaring 1<<32 = %v; want overflow", err)
+	}
+	// Beyond 2^53, float64 would round this to 0.
+	if got, err := getDistanceWith([]int{1 << 60}, []int{1<<60 + 1}, Absolute); err != nil || got != 1 {
+		t.Errorf("getDistanceWith(1<<60, 1<<60+1) = %d, %v; want 1", got, err)
+	}
+}
+
+func TestGetDistanceWithFraction(t *testing.T) {
+	// Half of each ID of ls weighs 1.5, so 1.5 of the weight moves 1 from 1 to 0.
+	_, err := getDistanceWith([]int{0, 1}, []int{0, 0, 0}, EarthMovers)
+	if err == nil || !strings.Contains(err.Error(), "1.5") {
+		t.Errorf("getDistanceWith with a fractional distance = %v; want an error mentioning 1.5", err)
+	}
+}
+
+func TestMetricText(t *testing.T) {
+	for _, m := range []Metric{Absolute, SquaredEuclidean, Chebyshev, EarthMovers} {
+		text, _ := m.MarshalText()
+		var got Metric
+		if err := got.UnmarshalText(text); err != nil || got != m {
+			t.Errorf("UnmarshalText(%q) = %v, %v; want %v", text, got, err, m)
+		}
+	}
+	var m Metric
+	if err := m.UnmarshalText([]byte("manhattan")); err == nil {
+		t.Errorf("UnmarshalText(manhattan) returned no error")
+	}
+}
+
+func TestWriteBreakdown(t *testing.T) {
+	bd, err := measure([]int{3, 1}, []int{2, 5}, SquaredEuclidean)
+	if err != nil {
+		t.Fatalf("measure returned error: %v", err)
+	}
+	var b strings.Builder
+	if err := writeBreakdown(&b, bd); err != nil {
+		t.Fatalf("writeBreakdown returned error: %v", err)
+	}
+	want := "" +
+		"     left  right  weight  distance\n" +
+		"        1      2       1         1\n" +
+		"        3      5       1         4\n" +
+		"  squared                        5\n"
+	if got := b.String(); got != want {
+		t.Errorf("writeBreakdown =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteBreakdownParts(t *testing.T) {
+	bd, err := measure([]int{0, 1}, []int{0, 0, 0}, EarthMovers)
+	if err != nil {
+		t.Fatalf("measure returned error: %v", err)
+	}
+	var b strings.Builder
+	if err := writeBreakdown(&b, bd); err != nil {
+		t.Fatalf("writeBreakdown returned error: %v", err)
+	}
+	want := "" +
+		"  left  right  weight  distance\n" +
+		"     0      0       1         0\n" +
+		"     0      0     0.5         0\n" +
+		"     1      0     0.5       0.5\n" +
+		"     1      0       1         1\n" +
+		"   emd                      1.5\n"
+	if got := b.String(); got != want {
+		t.Errorf("writeBreakdown =\n%s\nwant\n%s", got, want)
+	}
+}
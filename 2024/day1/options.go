@@ -0,0 +1,49 @@
+package day1
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+)
+
+// options choose which of the historians' lists to compare.
+type options struct {
+	// columns are the two columns to compare.
+	columns columnPair
+	// allPairs compares every pair of columns instead, adding up the results.
+	allPairs bool
+	// metric measures the distance between columns in part 1.
+	metric Metric
+	// breakdown makes Explain show how much each pair of IDs adds to the distance.
+	breakdown bool
+	// big computes distances and similarities in arbitrary precision.
+	big bool
+	// runSize is the most IDs of each list to hold in memory when streaming,
+	// or 0 to hold them all.
+	runSize int
+}
+
+// defaultOptions compare the first two columns as in the puzzle, and stream
+// up to a million IDs of each list at a time.
+var defaultOptions = options{columns: columnPair{0, 1}, metric: Absolute, runSize: 1 << 20}
+
+// registerFlags adds flags that set the options to fs, defaulting to their current values.
+func (o *options) registerFlags(fs *flag.FlagSet) {
+	fs.TextVar(&o.columns, "columns", o.columns, "the two columns to compare, counting from 0")
+	fs.BoolVar(&o.allPairs, "all-pairs", o.allPairs, "compare every pair of columns and add up the results")
+	fs.TextVar(&o.metric, "metric", o.metric, "distance metric for part 1: absolute, squared, chebyshev or emd")
+	fs.BoolVar(&o.breakdown, "breakdown", o.breakdown, "with --explain, show how much each pair of IDs adds to the distance")
+	fs.BoolVar(&o.big, "big", o.big, "compute in arbitrary precision; --explain shows answers too big for an int")
+	fs.IntVar(&o.runSize, "run-size", o.runSize, "with aoc run --stream, the most IDs of each list to hold in memory, or 0 for all of them")
+}
+
+// validate checks that the options make sense.
+func (o options) validate() error {
+	switch {
+	case o.runSize < 0:
+		return errors.New("negative run size")
+	case o.big && o.metric != Absolute:
+		return fmt.Errorf("--big needs the absolute metric; got %v", o.metric)
+	}
+	return nil
+}
@@ -0,0 +1,373 @@
+package day1
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"alger.au/aoc/input"
+)
+
+// streamLists reads pairs of location IDs from the given columns of in a line
+// at a time, calling f with each pair. Blank lines are skipped.
+func streamLists(in io.Reader, cols columnPair, f func(l, r int) error) error {
+	width := max(cols.left, cols.right) + 1
+	s := bufio.NewScanner(in)
+	for line := 1; s.Scan(); line++ {
+		text := strings.TrimSuffix(s.Text(), "\r")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		vals, err := input.Ints(text)
+		if err != nil {
+			return input.AtLine(line, err)
+		}
+		if len(vals) < width {
+			return input.AtLine(line, fmt.Errorf("expected at least %d values; got %d: %v", width, len(vals), text))
+		}
+		if err := f(vals[cols.left], vals[cols.right]); err != nil {
+			return input.AtLine(line, err)
+		}
+	}
+	return s.Err()
+}
+
+// streamConfig controls how much of each list is held in memory when streaming.
+type streamConfig struct {
+	// RunSize is the most values of a list to hold in memory before a sorted
+	// run is spilled to disk. If zero, every value is held in memory.
+	RunSize int
+	// TempDir is the directory for spilled runs, or the default temporary directory if empty.
+	TempDir string
+}
+
+// run is a sorted sequence of ints.
+type run interface {
+	// next returns the next value, or false at the end of the run.
+	next() (int, bool, error)
+}
+
+// sliceRun is a run held in memory.
+type sliceRun struct {
+	vals []int
+}
+
+func (r *sliceRun) next() (int, bool, error) {
+	if len(r.vals) == 0 {
+		return 0, false, nil
+	}
+	v := r.vals[0]
+	r.vals = r.vals[1:]
+	return v, true, nil
+}
+
+// fileRun is a run spilled to disk as little-endian 64-bit ints.
+type fileRun struct {
+	r   *bufio.Reader
+	buf [8]byte
+}
+
+func (r *fileRun) next() (int, bool, error) {
+	if _, err := io.ReadFull(r.r, r.buf[:]); err == io.EOF {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, err
+	}
+	return int(binary.LittleEndian.Uint64(r.buf[:])), true, nil
+}
+
+// runHead is the smallest unmerged value of a run.
+type runHead struct {
+	v int
+	r run
+}
+
+// runHeap is a min-heap of run heads.
+type runHeap []runHead
+
+func (h runHeap) Len() int           { return len(h) }
+func (h runHeap) Less(i, j int) bool { return h[i].v < h[j].v }
+func (h runHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *runHeap) Push(x any)        { *h = append(*h, x.(runHead)) }
+func (h *runHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// merger merges sorted runs into one sorted sequence.
+type merger struct {
+	heads runHeap
+	err   error
+}
+
+// add adds a run to merge.
+func (m *merger) add(r run) {
+	v, ok, err := r.next()
+	if err != nil {
+		m.err = err
+		return
+	}
+	if ok {
+		heap.Push(&m.heads, runHead{v, r})
+	}
+}
+
+// next returns the next smallest value, or false if the runs are exhausted or failed.
+// Failures are reported by Err.
+func (m *merger) next() (int, bool) {
+	if m.err != nil || len(m.heads) == 0 {
+		return 0, false
+	}
+	h := heap.Pop(&m.heads).(runHead)
+	m.add(h.r)
+	return h.v, true
+}
+
+// Err returns the first error reading the runs.
+func (m *merger) Err() error {
+	return m.err
+}
+
+// maxRuns is how many runs a sorter merges into one at a time, which bounds
+// the number of open files.
+const maxRuns = 64
+
+// sorter sorts lists that may not fit in memory by spilling sorted runs to temporary files.
+type sorter struct {
+	cfg streamConfig
+	n   int
+	buf []int
+	// levels holds the spilled runs. Each run in levels[i+1] was merged from
+	// maxRuns runs in levels[i], so each value is only rewritten once per level.
+	levels [][]*os.File
+}
+
+// add adds a value to sort.
+func (s *sorter) add(v int) error {
+	s.buf = append(s.buf, v)
+	s.n++
+	if s.cfg.RunSize > 0 && len(s.buf) >= s.cfg.RunSize {
+		return s.spill()
+	}
+	return nil
+}
+
+// spill writes the values in memory to a new sorted run.
+func (s *sorter) spill() error {
+	slices.Sort(s.buf)
+	m := &merger{}
+	m.add(&sliceRun{s.buf})
+	f, err := s.writeRun(m)
+	s.buf = s.buf[:0]
+	if err != nil {
+		return err
+	}
+	return s.addRun(0, f)
+}
+
+// addRun adds a run to a level, merging the level into one run of the next
+// level once it is full.
+func (s *sorter) addRun(level int, f *os.File) error {
+	if level == len(s.levels) {
+		s.levels = append(s.levels, nil)
+	}
+	s.levels[level] = append(s.levels[level], f)
+	if len(s.levels[level]) < maxRuns {
+		return nil
+	}
+
+	m, err := mergeRuns(s.levels[level])
+	if err != nil {
+		return err
+	}
+	merged, err := s.writeRun(m)
+	if err != nil {
+		// The level's runs are still held, so close can remove them.
+		return err
+	}
+	old := s.levels[level]
+	s.levels[level] = nil
+	return errors.Join(removeRuns(old), s.addRun(level+1, merged))
+}
+
+// writeRun writes merged values to a new run.
+// If writing fails, the run is removed.
+func (s *sorter) writeRun(m *merger) (*os.File, error) {
+	f, err := os.CreateTemp(s.cfg.TempDir, "day1-run-")
+	if err != nil {
+		return nil, err
+	}
+
+	w := bufio.NewWriter(f)
+	var b [8]byte
+	for {
+		v, ok := m.next()
+		if !ok {
+			break
+		}
+		binary.LittleEndian.PutUint64(b[:], uint64(v))
+		if _, err = w.Write(b[:]); err != nil {
+			break
+		}
+	}
+	if err = errors.Join(err, m.Err(), w.Flush()); err != nil {
+		return nil, errors.Join(err, removeRuns([]*os.File{f}))
+	}
+	return f, nil
+}
+
+// mergeRuns returns a merger over spilled runs, reading each from the start.
+func mergeRuns(runs []*os.File) (*merger, error) {
+	m := &merger{}
+	for _, f := range runs {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		m.add(&fileRun{r: bufio.NewReader(f)})
+	}
+	return m, m.Err()
+}
+
+// removeRuns closes and removes spilled runs.
+func removeRuns(runs []*os.File) error {
+	var errs []error
+	for _, f := range runs {
+		errs = append(errs, f.Close(), os.Remove(f.Name()))
+	}
+	return errors.Join(errs...)
+}
+
+// sorted returns a merger over every value added, in sorted order.
+func (s *sorter) sorted() (*merger, error) {
+	slices.Sort(s.buf)
+	m, err := mergeRuns(slices.Concat(s.levels...))
+	if err != nil {
+		return nil, err
+	}
+	m.add(&sliceRun{s.buf})
+	return m, m.Err()
+}
+
+// close removes any spilled runs.
+func (s *sorter) close() error {
+	err := removeRuns(slices.Concat(s.levels...))
+	s.levels = nil
+	return err
+}
+
+// sortLists reads and sorts the lists in the given columns of in.
+// The returned function removes any spilled runs.
+func sortLists(in io.Reader, cols columnPair, cfg streamConfig) (*merger, *merger, func() error, error) {
+	ls, rs := &sorter{cfg: cfg}, &sorter{cfg: cfg}
+	closeAll := func() error {
+		return errors.Join(ls.close(), rs.close())
+	}
+	err := streamLists(in, cols, func(l, r int) error {
+		return errors.Join(ls.add(l), rs.add(r))
+	})
+	if err != nil {
+		closeAll()
+		return nil, nil, nil, err
+	}
+	if ls.n != rs.n {
+		closeAll()
+		return nil, nil, nil, fmt.Errorf("lists should be same length; got %d and %d", ls.n, rs.n)
+	}
+
+	lm, err := ls.sorted()
+	if err != nil {
+		closeAll()
+		return nil, nil, nil, err
+	}
+	rm, err := rs.sorted()
+	if err != nil {
+		closeAll()
+		return nil, nil, nil, err
+	}
+	return lm, rm, closeAll, nil
+}
+
+// streamDistance calculates the distance between the lists in the given
+// columns of in, like getDistance.
+func streamDistance(in io.Reader, cols columnPair, cfg streamConfig) (int, error) {
+	ls, rs, closeAll, err := sortLists(in, cols, cfg)
+	if err != nil {
+		return -1, err
+	}
+	defer closeAll()
+
+	d := 0
+	for {
+		l, ok := ls.next()
+		if !ok {
+			break
+		}
+		r, _ := rs.next()
+		diff, err := absDiff(l, r)
+		if err != nil {
+			return -1, err
+		}
+		if d, err = add(d, diff); err != nil {
+			return -1, err
+		}
+	}
+	if err := errors.Join(ls.Err(), rs.Err()); err != nil {
+		return -1, err
+	}
+	return d, nil
+}
+
+// streamSimilarity calculates the similarity of the lists in the given
+// columns of in, like getSimilarity.
+func streamSimilarity(in io.Reader, cols columnPair, cfg streamConfig) (int, error) {
+	ls, rs, closeAll, err := sortLists(in, cols, cfg)
+	if err != nil {
+		return -1, err
+	}
+	defer closeAll()
+
+	// Join the sorted lists on equal values.
+	sim := 0
+	l, lok := ls.next()
+	r, rok := rs.next()
+	for lok && rok {
+		switch {
+		case l < r:
+			l, lok = ls.next()
+		case l > r:
+			r, rok = rs.next()
+		default:
+			v, nl, nr := l, 0, 0
+			for lok && l == v {
+				nl++
+				l, lok = ls.next()
+			}
+			for rok && r == v {
+				nr++
+				r, rok = rs.next()
+			}
+			score, err := mul(nl, nr)
+			if err == nil {
+				score, err = mul(v, score)
+			}
+			if err == nil {
+				sim, err = add(sim, score)
+			}
+			if err != nil {
+				return -1, err
+			}
+		}
+	}
+	if err := errors.Join(ls.Err(), rs.Err()); err != nil {
+		return -1, err
+	}
+	return sim, nil
+}
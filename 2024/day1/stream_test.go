@@ -0,0 +1,125 @@
+package day1
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"alger.au/aoc/input"
+)
+
+// randomLists generates lists of n location IDs, with plenty of repeats.
+func randomLists(r *rand.Rand, n int) ([]int, []int, []byte) {
+	var b bytes.Buffer
+	ls := make([]int, n)
+	rs := make([]int, n)
+	for i := range n {
+		ls[i] = r.IntN(50)
+		rs[i] = r.IntN(50)
+		fmt.Fprintf(&b, "%d   %d\n", ls[i], rs[i])
+	}
+	return ls, rs, b.Bytes()
+}
+
+func TestStreamMatchesInMemory(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, n := range []int{0, 1, 6, 100, 1000} {
+		ls, rs, data := randomLists(r, n)
+		wantDist, err := getDistance(ls, rs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wantSim, err := getSimilarity(ls, rs)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, runSize := range []int{0, 7, 64} {
+			cfg := streamConfig{RunSize: runSize, TempDir: t.TempDir()}
+			if got, err := streamDistance(bytes.NewReader(data), defaultOptions.columns, cfg); err != nil || got != wantDist {
+				t.Errorf("streamDistance(%d lines, run size %d) = %d, %v; want %d", n, runSize, got, err, wantDist)
+			}
+			if got, err := streamSimilarity(bytes.NewReader(data), defaultOptions.columns, cfg); err != nil || got != wantSim {
+				t.Errorf("streamSimilarity(%d lines, run size %d) = %d, %v; want %d", n, runSize, got, err, wantSim)
+			}
+
+			entries, err := os.ReadDir(cfg.TempDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(entries) > 0 {
+				t.Errorf("run size %d left %d temporary files", runSize, len(entries))
+			}
+		}
+	}
+}
+
+func TestStreamColumns(t *testing.T) {
+	data := "3 4 9\n4 3 9\n"
+	for _, tt := range []struct {
+		cols columnPair
+		want int
+	}{
+		{columnPair{0, 1}, 0},
+		{columnPair{0, 2}, 11},
+		{columnPair{2, 1}, 11},
+	} {
+		if got, err := streamDistance(strings.NewReader(data), tt.cols, streamConfig{}); err != nil || got != tt.want {
+			t.Errorf("streamDistance(%q, %v) = %d, %v; want %d", data, tt.cols, got, err, tt.want)
+		}
+	}
+}
+
+func TestStreamListsError(t *testing.T) {
+	data := "3   4   5\r\n\n4   3\n"
+	_, err := streamDistance(strings.NewReader(data), columnPair{0, 2}, streamConfig{})
+	var ie *input.Error
+	if !errors.As(err, &ie) || ie.Line != 3 {
+		t.Errorf("streamDistance(%q) = %v; want error on line 3", data, err)
+	}
+}
+
+func TestSorterLevels(t *testing.T) {
+	dir := t.TempDir()
+	s := &sorter{cfg: streamConfig{RunSize: 1, TempDir: dir}}
+	n := maxRuns*maxRuns + 1
+	for v := n; v > 0; v-- {
+		if err := s.add(v); err != nil {
+			t.Fatalf("add(%d) returned error: %v", v, err)
+		}
+	}
+
+	// maxRuns² runs merge into one run two levels up, leaving the last on its own.
+	var got []int
+	for _, runs := range s.levels {
+		got = append(got, len(runs))
+	}
+	if want := []int{1, 0, 1}; !slices.Equal(got, want) {
+		t.Errorf("runs per level = %v; want %v", got, want)
+	}
+
+	m, err := s.sorted()
+	if err != nil {
+		t.Fatalf("sorted returned error: %v", err)
+	}
+	for want := 1; want <= n; want++ {
+		if v, ok := m.next(); !ok || v != want {
+			t.Fatalf("value %d = %d, %t; want %d", want, v, ok, want)
+		}
+	}
+	if _, ok := m.next(); ok {
+		t.Errorf("sorted returned more than %d values", n)
+	}
+
+	if err := s.close(); err != nil {
+		t.Fatalf("close returned error: %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) > 0 {
+		t.Errorf("close left %d temporary files", len(entries))
+	}
+}
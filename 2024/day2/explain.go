@@ -0,0 +1,129 @@
+package day2
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Problem is why a pair of adjacent levels makes a report unsafe.
+type Problem int
+
+const (
+	// NoProblem means the report is safe.
+	NoProblem Problem = iota
+	// TrendFlip means the levels change against the direction of the report.
+	TrendFlip
+	// StepTooSmall means the levels change by less than the minimum step, or not at all.
+	StepTooSmall
+	// StepTooLarge means the levels change by more than the maximum step.
+	StepTooLarge
+)
+
+var problemNames = []string{"safe", "trend flip", "step too small", "step too large"}
+
+func (p Problem) String() string {
+	if p < 0 || int(p) >= len(problemNames) {
+		return fmt.Sprintf("Problem(%d)", int(p))
+	}
+	return problemNames[p]
+}
+
+// Verdict explains whether a report is safe.
+type Verdict struct {
+	Problem Problem
+	// Index is the index of the first level in the first unsafe pair of adjacent levels.
+	Index int
+	// Diff is the change in level from Index to the next level.
+	Diff int
+	// Fix is the index of a level that the Problem Dampener can remove to make
+	// the report safe, or -1 if the report is already safe or cannot be fixed.
+	Fix int
+}
+
+// Safe returns whether the report is safe without the Problem Dampener.
+func (v Verdict) Safe() bool {
+	return v.Problem == NoProblem
+}
+
+func (v Verdict) String() string {
+	if v.Safe() {
+		return v.Problem.String()
+	}
+	return fmt.Sprintf("%v (%+d)", v.Problem, v.Diff)
+}
+
+// trend returns 1 if a report should be increasing and -1 if it should be decreasing.
+// Like isSafe, it compares the first and last levels.
+func trend(r report) int {
+	if len(r) > 0 && r[0] > r[len(r)-1] {
+		return -1
+	}
+	return 1
+}
+
+// firstProblem finds the first unsafe pair of adjacent levels in a report.
+func (p SafetyPolicy) firstProblem(r report) Verdict {
+	dir := p.direction(r)
+	for i := 0; i+1 < len(r); i++ {
+		if problem := p.check(r[i], r[i+1], dir); problem != NoProblem {
+			return Verdict{Problem: problem, Index: i, Diff: r[i+1] - r[i], Fix: -1}
+		}
+	}
+	return Verdict{Fix: -1}
+}
+
+// Explain explains why a report is unsafe under the policy, and which level the
+// Problem Dampener can remove to fix it.
+func (p SafetyPolicy) Explain(r report) Verdict {
+	v := p.firstProblem(r)
+	if v.Safe() {
+		return v
+	}
+	v.Fix, _ = p.dampen(r)
+	return v
+}
+
+// Explain explains why a report is unsafe, and which level the Problem Dampener
+// can remove to fix it.
+func Explain(r report) Verdict {
+	return DefaultPolicy.Explain(r)
+}
+
+// highlight formats a report with the pair of levels starting at i in brackets.
+func highlight(r report, i int) string {
+	var b strings.Builder
+	for j, l := range r {
+		if j > 0 {
+			b.WriteByte(' ')
+		}
+		if j == i {
+			b.WriteByte('[')
+		}
+		b.WriteString(strconv.Itoa(l))
+		if j == i+1 {
+			b.WriteByte(']')
+		}
+	}
+	return b.String()
+}
+
+// writeExplanations writes each report that is unsafe under a policy, numbered
+// by line, with its first unsafe pair of levels in brackets.
+func writeExplanations(w io.Writer, reports []report, p SafetyPolicy) error {
+	for i, r := range reports {
+		v := p.Explain(r)
+		if v.Safe() {
+			continue
+		}
+		fix := "unsafe even with the Problem Dampener"
+		if v.Fix >= 0 {
+			fix = fmt.Sprintf("safe after removing %d at index %d", r[v.Fix], v.Fix)
+		}
+		if _, err := fmt.Fprintf(w, "%d: %s: %v; %s\n", i+1, highlight(r, v.Index), v, fix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
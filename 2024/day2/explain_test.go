@@ -0,0 +1,59 @@
+package day2
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestExplain(t *testing.T) {
+	tests := []struct {
+		report report
+		want   Verdict
+	}{
+		{report{7, 6, 4, 2, 1}, Verdict{NoProblem, 0, 0, -1}},
+		{report{1, 2, 7, 8, 9}, Verdict{StepTooLarge, 1, 5, -1}},
+		{report{9, 7, 6, 2, 1}, Verdict{StepTooLarge, 2, -4, -1}},
+		{report{1, 3, 2, 4, 5}, Verdict{TrendFlip, 1, -1, 1}},
+		{report{8, 6, 4, 4, 1}, Verdict{StepTooSmall, 2, 0, 2}},
+		{report{1, 3, 6, 7, 9}, Verdict{NoProblem, 0, 0, -1}},
+		{report{5, 1, 2, 3, 4}, Verdict{StepTooLarge, 0, -4, 0}},
+		{report{}, Verdict{NoProblem, 0, 0, -1}},
+	}
+	for _, tt := range tests {
+		got := Explain(tt.report)
+		if got != tt.want {
+			t.Errorf("Explain(%v) = %+v; want %+v", tt.report, got, tt.want)
+		}
+		if got.Safe() != isSafe(tt.report) {
+			t.Errorf("Explain(%v).Safe() = %t; isSafe = %t", tt.report, got.Safe(), isSafe(tt.report))
+		}
+		if !got.Safe() && (got.Fix >= 0) != isSafeDampened(tt.report) {
+			t.Errorf("Explain(%v) = %+v; isSafeDampened = %t", tt.report, got, isSafeDampened(tt.report))
+		}
+	}
+}
+
+func TestWriteExplanations(t *testing.T) {
+	data, err := os.ReadFile("testdata/example.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	reports, err := readReports(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b strings.Builder
+	if err := writeExplanations(&b, reports, DefaultPolicy); err != nil {
+		t.Fatal(err)
+	}
+	want := "" +
+		"2: 1 [2 7] 8 9: step too large (+5); unsafe even with the Problem Dampener\n" +
+		"3: 9 7 [6 2] 1: step too large (-4); unsafe even with the Problem Dampener\n" +
+		"4: 1 [3 2] 4 5: trend flip (-1); safe after removing 3 at index 1\n" +
+		"5: 8 6 [4 4] 1: step too small (+0); safe after removing 4 at index 2\n"
+	if got := b.String(); got != want {
+		t.Errorf("writeExplanations =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,142 @@
+package day2
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+)
+
+// Direction is the direction the levels of a report must go in.
+type Direction int
+
+const (
+	// Either allows reports to be increasing or decreasing.
+	Either Direction = iota
+	// Increasing only allows increasing reports.
+	Increasing
+	// Decreasing only allows decreasing reports.
+	Decreasing
+)
+
+var directionNames = []string{"either", "increasing", "decreasing"}
+
+func (d Direction) String() string {
+	if d < 0 || int(d) >= len(directionNames) {
+		return fmt.Sprintf("Direction(%d)", int(d))
+	}
+	return directionNames[d]
+}
+
+// MarshalText encodes a direction as its name.
+func (d Direction) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText decodes a direction from its name.
+func (d *Direction) UnmarshalText(text []byte) error {
+	for i, name := range directionNames {
+		if name == string(text) {
+			*d = Direction(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown direction: %q", text)
+}
+
+// SafetyPolicy is the set of rules that decide whether a report is safe.
+type SafetyPolicy struct {
+	// MinStep and MaxStep bound how much adjacent levels may differ.
+	MinStep, MaxStep int
+	// Monotone requires the levels to all increase or all decrease.
+	Monotone bool
+	// AllowEqual allows adjacent levels to be equal, whatever MinStep is.
+	AllowEqual bool
+	// Direction restricts which way a monotone report may go.
+	Direction Direction
+}
+
+// DefaultPolicy is the policy of the Red-Nosed reactor engineers.
+var DefaultPolicy = SafetyPolicy{MinStep: 1, MaxStep: 3, Monotone: true, Direction: Either}
+
+// Validate checks that the policy makes sense.
+func (p SafetyPolicy) Validate() error {
+	switch {
+	case p.MinStep < 0:
+		return fmt.Errorf("negative minimum step: %d", p.MinStep)
+	case p.MaxStep < p.MinStep:
+		return fmt.Errorf("maximum step %d is less than minimum step %d", p.MaxStep, p.MinStep)
+	case p.Direction < Either || p.Direction > Decreasing:
+		return fmt.Errorf("unknown direction: %v", p.Direction)
+	case p.Direction != Either && !p.Monotone:
+		return errors.New("a direction needs a monotone policy")
+	}
+	return nil
+}
+
+// RegisterFlags adds flags that set the policy to fs, defaulting to its current rules.
+func (p *SafetyPolicy) RegisterFlags(fs *flag.FlagSet) {
+	fs.IntVar(&p.MinStep, "min-step", p.MinStep, "smallest safe difference between adjacent levels")
+	fs.IntVar(&p.MaxStep, "max-step", p.MaxStep, "largest safe difference between adjacent levels")
+	fs.BoolVar(&p.Monotone, "monotone", p.Monotone, "require levels to all increase or all decrease")
+	fs.BoolVar(&p.AllowEqual, "allow-equal", p.AllowEqual, "allow adjacent levels to be equal")
+	fs.TextVar(&p.Direction, "direction", p.Direction, "direction of monotone reports: either, increasing or decreasing")
+}
+
+// check finds the problem, if any, with a step from level a to level b in a
+// report that should be increasing (dir 1) or decreasing (dir -1).
+func (p SafetyPolicy) check(a, b level, dir int) Problem {
+	diff := b - a
+	size := max(diff, -diff)
+	switch {
+	case diff == 0 && p.AllowEqual:
+		return NoProblem
+	case diff == 0:
+		return StepTooSmall
+	case p.Monotone && diff*dir < 0:
+		return TrendFlip
+	case size < p.MinStep:
+		return StepTooSmall
+	case size > p.MaxStep:
+		return StepTooLarge
+	}
+	return NoProblem
+}
+
+// stepOK determines if a step from level a to level b is safe in direction dir.
+func (p SafetyPolicy) stepOK(a, b level, dir int) bool {
+	return p.check(a, b, dir) == NoProblem
+}
+
+var (
+	bothWays = []int{1, -1}
+	upOnly   = []int{1}
+	downOnly = []int{-1}
+)
+
+// directions lists the directions a report may go in: 1 for increasing and -1 for decreasing.
+func (p SafetyPolicy) directions() []int {
+	switch {
+	case !p.Monotone:
+		// Any direction will do.
+		return upOnly
+	case p.Direction == Increasing:
+		return upOnly
+	case p.Direction == Decreasing:
+		return downOnly
+	default:
+		return bothWays
+	}
+}
+
+// direction returns the direction a report should go in.
+// With Either, this compares its first and last levels.
+func (p SafetyPolicy) direction(r report) int {
+	switch p.Direction {
+	case Increasing:
+		return 1
+	case Decreasing:
+		return -1
+	default:
+		return trend(r)
+	}
+}
@@ -0,0 +1,136 @@
+package day2
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestSafetyPolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy SafetyPolicy
+		report report
+		want   bool
+	}{
+		{"default", DefaultPolicy, report{7, 6, 4, 2, 1}, true},
+		{"wider steps", SafetyPolicy{MinStep: 1, MaxStep: 5, Monotone: true}, report{1, 2, 7, 8, 9}, true},
+		{"bigger steps", SafetyPolicy{MinStep: 2, MaxStep: 3, Monotone: true}, report{1, 3, 6, 7, 9}, false},
+		{"equal not allowed", DefaultPolicy, report{1, 2, 2, 3}, false},
+		{"equal allowed", SafetyPolicy{MinStep: 1, MaxStep: 3, Monotone: true, AllowEqual: true}, report{1, 2, 2, 3}, true},
+		{"all equal allowed", SafetyPolicy{MinStep: 1, MaxStep: 3, Monotone: true, AllowEqual: true}, report{4, 4, 4}, true},
+		{"not monotone", SafetyPolicy{MinStep: 1, MaxStep: 3}, report{1, 3, 2, 4, 5}, true},
+		{"increasing only", SafetyPolicy{MinStep: 1, MaxStep: 3, Monotone: true, Direction: Increasing}, report{7, 6, 4, 2, 1}, false},
+		{"decreasing only", SafetyPolicy{MinStep: 1, MaxStep: 3, Monotone: true, Direction: Decreasing}, report{7, 6, 4, 2, 1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.IsSafe(tt.report); got != tt.want {
+				t.Errorf("%+v.IsSafe(%v) = %t; want %t", tt.policy, tt.report, got, tt.want)
+			}
+		})
+	}
+}
+
+// randomPolicy generates a valid policy.
+func randomPolicy(r *rand.Rand) SafetyPolicy {
+	p := SafetyPolicy{
+		MinStep:    r.IntN(3),
+		Monotone:   r.IntN(4) > 0,
+		AllowEqual: r.IntN(2) == 0,
+	}
+	p.MaxStep = p.MinStep + r.IntN(4)
+	if p.Monotone {
+		p.Direction = Direction(r.IntN(3))
+	}
+	return p
+}
+
+// isSafeBruteWith determines if a report is safe under a policy by trying every direction.
+func isSafeBruteWith(p SafetyPolicy, r report) bool {
+	for _, dir := range []int{1, -1} {
+		if (p.Direction == Increasing && dir < 0) || (p.Direction == Decreasing && dir > 0) {
+			continue
+		}
+		ok := true
+		for i := 0; i+1 < len(r); i++ {
+			ok = ok && p.stepOK(r[i], r[i+1], dir)
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSafetyPolicyMatchesBrute(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+	for range 20000 {
+		p := randomPolicy(r)
+		rep := randomReport(r)
+
+		safe := p.IsSafe(rep)
+		if want := isSafeBruteWith(p, rep); safe != want {
+			t.Fatalf("%+v.IsSafe(%v) = %t; brute force says %t", p, rep, safe, want)
+		}
+		if got := p.Explain(rep).Safe(); got != safe {
+			t.Fatalf("%+v.Explain(%v).Safe() = %t; IsSafe = %t", p, rep, got, safe)
+		}
+
+		dampened := safe
+		for i := range rep {
+			dampened = dampened || isSafeBruteWith(p, slices.Concat(rep[:i], rep[i+1:]))
+		}
+		if got := p.IsSafeDampened(rep); got != dampened {
+			t.Fatalf("%+v.IsSafeDampened(%v) = %t; brute force says %t", p, rep, got, dampened)
+		}
+		if got := p.MinRemovals(rep); (got == 0) != safe || (got <= 1) != dampened {
+			t.Fatalf("%+v.MinRemovals(%v) = %d; safe %t, dampened %t", p, rep, got, safe, dampened)
+		}
+	}
+}
+
+func TestSafetyPolicyValidate(t *testing.T) {
+	tests := []struct {
+		policy SafetyPolicy
+		ok     bool
+	}{
+		{DefaultPolicy, true},
+		{SafetyPolicy{MinStep: 0, MaxStep: 0}, true},
+		{SafetyPolicy{MinStep: -1, MaxStep: 3}, false},
+		{SafetyPolicy{MinStep: 4, MaxStep: 3}, false},
+		{SafetyPolicy{MinStep: 1, MaxStep: 3, Direction: Increasing}, false},
+		{SafetyPolicy{MinStep: 1, MaxStep: 3, Monotone: true, Direction: 3}, false},
+	}
+	for _, tt := range tests {
+		if err := tt.policy.Validate(); (err == nil) != tt.ok {
+			t.Errorf("%+v.Validate() = %v; want ok %t", tt.policy, err, tt.ok)
+		}
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	s := &solver{policy: DefaultPolicy}
+	args := []string{"--min-step", "2", "--max-step", "4", "--allow-equal", "--direction", "decreasing"}
+	if err := s.Configure(args); err != nil {
+		t.Fatalf("Configure(%v) returned error: %v", args, err)
+	}
+	want := SafetyPolicy{MinStep: 2, MaxStep: 4, Monotone: true, AllowEqual: true, Direction: Decreasing}
+	if s.policy != want {
+		t.Errorf("Configure(%v) set policy %+v; want %+v", args, s.policy, want)
+	}
+
+	for _, args := range [][]string{
+		{"--direction", "sideways"},
+		{"--monotone=false", "--direction", "increasing"},
+		{"extra"},
+	} {
+		s := &solver{policy: DefaultPolicy}
+		if err := s.Configure(args); err == nil {
+			t.Errorf("Configure(%v) returned no error", args)
+		}
+		if s.policy != DefaultPolicy {
+			t.Errorf("Configure(%v) changed the policy to %+v", args, s.policy)
+		}
+	}
+}
@@ -1,47 +1,47 @@
-package main
+package day2
 
 import (
+	"flag"
 	"fmt"
-	"log"
-	"os"
-	"slices"
-	"strconv"
-	"strings"
+	"io"
+
+	"alger.au/aoc/input"
+	"alger.au/aoc/puzzle"
 )
 
-const dataPath = "../data/day2.txt"
+const dataPath = "2024/data/day2.txt"
+
+func init() {
+	puzzle.Register(2024, 2, dataPath, &solver{policy: DefaultPolicy})
+}
 
 type level = int
 type report = []level
 
-// readReports reads reports from a file.
-func readReports(path string) ([]report, error) {
-	text, err := os.ReadFile(path)
-	if err != nil {
-		return nil, err
-	}
-
-	lines := strings.Split(string(text), "\n")
+// readReports reads reports from data.
+func readReports(data []byte) ([]report, error) {
+	return input.MapNonBlank(input.Lines(data), input.Ints)
+}
 
-	var reports []report
-	for _, line := range lines {
-		if len(line) == 0 {
+// safeFrom determines if a report is safe in direction dir from index from
+// onwards, ignoring the level at index skip (or none if skip is -1).
+func (p SafetyPolicy) safeFrom(r report, from, skip, dir int) bool {
+	prev := -1
+	for i := from; i < len(r); i++ {
+		if i == skip {
 			continue
 		}
-
-		levels := strings.Split(line, " ")
-		var report report
-		for _, level := range levels {
-			i, err := strconv.Atoi(level)
-			if err != nil {
-				return nil, fmt.Errorf("parsing int: %w", err)
-			}
-			report = append(report, i)
+		if prev >= 0 && !p.stepOK(r[prev], r[i], dir) {
+			return false
 		}
-		reports = append(reports, report)
+		prev = i
 	}
+	return true
+}
 
-	return reports, nil
+// IsSafe determines if a report is safe under the policy.
+func (p SafetyPolicy) IsSafe(r report) bool {
+	return p.safeFrom(r, 0, -1, p.direction(r))
 }
 
 // isSafe determines if a report is safe.
@@ -49,63 +49,145 @@ func readReports(path string) ([]report, error) {
 // - is increasing or decreasing, and
 // - has adjacent levels differing by at least 1 and at most 3.
 func isSafe(report report) bool {
-	if len(report) < 2 {
-		return true
-	}
+	return DefaultPolicy.IsSafe(report)
+}
 
-	report = slices.Clone(report)
-	if report[0] > report[len(report)-1] {
-		slices.Reverse(report)
+// dampen finds a level that the Problem Dampener can remove to make a report safe.
+// It returns -1 and true if the report is already safe, and false if no single
+// removal makes it safe.
+func (p SafetyPolicy) dampen(r report) (int, bool) {
+	if p.IsSafe(r) {
+		return -1, true
 	}
-	// We can now assume that the levels should be increasing.
-
-	last := report[0] - 1
-	for _, level := range report {
-		diff := level - last
-		if diff < 1 || diff > 3 {
-			return false
+	for _, dir := range p.directions() {
+		// The prefix before the first unsafe step is safe, and one of the
+		// levels in that step must be removed.
+		k := 0
+		for k+1 < len(r) && p.stepOK(r[k], r[k+1], dir) {
+			k++
+		}
+		if k+1 == len(r) {
+			// Safe in this direction, which IsSafe would have caught.
+			return -1, true
+		}
+		for _, skip := range [...]int{k, k + 1} {
+			if p.safeFrom(r, max(k-1, 0), skip, dir) {
+				return skip, true
+			}
 		}
-		last = level
 	}
-	return true
+	return -1, false
+}
+
+// IsSafeDampened determines if a report is safe under the policy when applying the Problem Dampener.
+// It takes linear time and does not allocate.
+func (p SafetyPolicy) IsSafeDampened(r report) bool {
+	_, ok := p.dampen(r)
+	return ok
 }
 
 // isSafeDampened determines if a report is safe when applying the Problem Dampener.
 func isSafeDampened(r report) bool {
-	for i := range r {
-		// Remove the ith element and check for safety.
-		// This could be made more efficient by avoiding re-slicing.
-		dampened := slices.Concat(r[:i], r[i+1:])
-		if isSafe(dampened) {
-			return true
+	return DefaultPolicy.IsSafeDampened(r)
+}
+
+// MinRemovals finds the fewest levels that must be removed to make a report safe under the policy.
+// It finds the longest safe subsequence of levels in each direction.
+func (p SafetyPolicy) MinRemovals(r report) int {
+	longest := min(len(r), 1)
+	// lengths[i] is the length of the longest safe subsequence ending at level i.
+	lengths := make([]int, len(r))
+	for _, dir := range p.directions() {
+		for i := range r {
+			lengths[i] = 1
+			for j := range i {
+				if p.stepOK(r[j], r[i], dir) {
+					lengths[i] = max(lengths[i], lengths[j]+1)
+				}
+			}
+			longest = max(longest, lengths[i])
 		}
 	}
-	return false
+	return len(r) - longest
 }
 
-// countSafeReports counts how many reports are safe.
-// If damp is set, use the engineers' Problem Dampener.
-func countSafeReports(reports []report, damp bool) int {
+// MinRemovals finds the fewest levels that must be removed to make a report safe.
+func MinRemovals(r report) int {
+	return DefaultPolicy.MinRemovals(r)
+}
+
+// countSafeReports counts how many reports are safe under a policy after
+// removing at most k levels from each.
+// With k = 1, this is the engineers' Problem Dampener.
+func countSafeReports(reports []report, k int, p SafetyPolicy) int {
 	n := 0
 	for _, report := range reports {
-		if isSafe(report) {
-			n += 1
-		} else if damp && isSafeDampened(report) {
-			// Only run the more expensive code path if we need to.
+		switch {
+		case p.IsSafe(report):
 			n += 1
+		case k == 1:
+			// Much faster than finding the minimum removals.
+			if p.IsSafeDampened(report) {
+				n += 1
+			}
+		case k > 1:
+			if p.MinRemovals(report) <= k {
+				n += 1
+			}
 		}
 	}
 	return n
 }
 
-func main() {
-	reports, err := readReports(dataPath)
+// solver solves day 2.
+type solver struct {
+	// policy decides which reports are safe. Configure can change it.
+	policy SafetyPolicy
+}
+
+// Parse reads the reports.
+func (solver) Parse(data []byte) ([]report, error) {
+	reports, err := readReports(data)
 	if err != nil {
-		log.Fatalf("reading reports: %v", err)
+		return nil, fmt.Errorf("reading reports: %w", err)
 	}
+	return reports, nil
+}
 
-	c := countSafeReports(reports, false)
-	fmt.Printf("1: %d\n", c)
-	c = countSafeReports(reports, true)
-	fmt.Printf("2: %d\n", c)
+// Part1 counts the safe reports.
+func (s *solver) Part1(reports []report) (puzzle.Answer, error) {
+	return puzzle.Answer(countSafeReports(reports, 0, s.policy)), nil
+}
+
+// Part2 counts the safe reports using the Problem Dampener.
+func (s *solver) Part2(reports []report) (puzzle.Answer, error) {
+	return puzzle.Answer(countSafeReports(reports, 1, s.policy)), nil
+}
+
+// Explain prints the unsafe reports and why they are unsafe.
+func (s *solver) Explain(w io.Writer, reports []report) error {
+	return writeExplanations(w, reports, s.policy)
+}
+
+// Summarize summarizes the safety of the reports.
+func (s *solver) Summarize(reports []report) (any, error) {
+	return computeStats(reports, s.policy), nil
+}
+
+// Configure sets the safety policy from command-line flags.
+func (s *solver) Configure(args []string) error {
+	p := DefaultPolicy
+	fs := flag.NewFlagSet("2024 day 2", flag.ContinueOnError)
+	p.RegisterFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	s.policy = p
+	return nil
 }
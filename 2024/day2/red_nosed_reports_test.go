@@ -0,0 +1,254 @@
+package day2
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"testing"
+
+	"alger.au/aoc/puzzle/puzzletest"
+)
+
+func TestIsSafe(t *testing.T) {
+	tests := []struct {
+		report report
+		want   bool
+	}{
+		{report{7, 6, 4, 2, 1}, true},
+		{report{1, 2, 7, 8, 9}, false},
+		{report{9, 7, 6, 2, 1}, false},
+		{report{1, 3, 2, 4, 5}, false},
+		{report{8, 6, 4, 4, 1}, false},
+		{report{1, 3, 6, 7, 9}, true},
+		{report{}, true},
+		{report{5}, true},
+	}
+	for _, tt := range tests {
+		if got := isSafe(tt.report); got != tt.want {
+			t.Errorf("isSafe(%v) = %t; want %t", tt.report, got, tt.want)
+		}
+	}
+}
+
+func TestIsSafeDampened(t *testing.T) {
+	tests := []struct {
+		report report
+		want   bool
+	}{
+		{report{7, 6, 4, 2, 1}, true},
+		{report{1, 2, 7, 8, 9}, false},
+		{report{9, 7, 6, 2, 1}, false},
+		{report{1, 3, 2, 4, 5}, true},
+		{report{8, 6, 4, 4, 1}, true},
+		{report{1, 3, 6, 7, 9}, true},
+		// Removing the first level fixes the direction.
+		{report{5, 1, 2, 3, 4}, true},
+		// Removing the last level fixes the direction.
+		{report{1, 2, 3, 4, 1}, true},
+	}
+	for _, tt := range tests {
+		if got := isSafeDampened(tt.report); got != tt.want {
+			t.Errorf("isSafeDampened(%v) = %t; want %t", tt.report, got, tt.want)
+		}
+	}
+}
+
+// isSafeDampenedBrute determines if a report is safe when applying the Problem
+// Dampener by trying every removal.
+func isSafeDampenedBrute(r report) bool {
+	if isSafeBrute(r) {
+		return true
+	}
+	for i := range r {
+		if isSafeBrute(slices.Concat(r[:i], r[i+1:])) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSafeBrute determines if a report is safe by reversing decreasing reports.
+func isSafeBrute(r report) bool {
+	r = slices.Clone(r)
+	if len(r) > 0 && r[0] > r[len(r)-1] {
+		slices.Reverse(r)
+	}
+	for i := 0; i+1 < len(r); i++ {
+		if diff := r[i+1] - r[i]; diff < 1 || diff > 3 {
+			return false
+		}
+	}
+	return true
+}
+
+// randomReport generates a report that is often nearly safe.
+func randomReport(r *rand.Rand) report {
+	rep := make(report, r.IntN(10))
+	dir := 1 - 2*r.IntN(2)
+	for i := range rep {
+		if i == 0 {
+			rep[i] = r.IntN(100)
+			continue
+		}
+		step := dir * (1 + r.IntN(3))
+		if r.IntN(5) == 0 {
+			step = r.IntN(11) - 5
+		}
+		rep[i] = rep[i-1] + step
+	}
+	return rep
+}
+
+func TestIsSafeDampenedMatchesBrute(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for range 100000 {
+		rep := randomReport(r)
+		if got, want := isSafe(rep), isSafeBrute(rep); got != want {
+			t.Fatalf("isSafe(%v) = %t; brute force says %t", rep, got, want)
+		}
+		if got, want := isSafeDampened(rep), isSafeDampenedBrute(rep); got != want {
+			t.Fatalf("isSafeDampened(%v) = %t; brute force says %t", rep, got, want)
+		}
+		if fix, ok := DefaultPolicy.dampen(rep); fix >= 0 && !isSafe(slices.Concat(rep[:fix], rep[fix+1:])) {
+			t.Fatalf("dampen(%v) = %d, %t; removing it is not safe", rep, fix, ok)
+		}
+	}
+}
+
+func TestMinRemovals(t *testing.T) {
+	tests := []struct {
+		report report
+		want   int
+	}{
+		{report{7, 6, 4, 2, 1}, 0},
+		{report{1, 2, 7, 8, 9}, 2},
+		{report{9, 7, 6, 2, 1}, 2},
+		{report{1, 3, 2, 4, 5}, 1},
+		{report{8, 6, 4, 4, 1}, 1},
+		{report{1, 3, 6, 7, 9}, 0},
+		{report{1, 9, 2, 9, 3, 4}, 2},
+		{report{5, 5, 5, 5}, 3},
+		{report{}, 0},
+		{report{5}, 0},
+	}
+	for _, tt := range tests {
+		if got := MinRemovals(tt.report); got != tt.want {
+			t.Errorf("MinRemovals(%v) = %d; want %d", tt.report, got, tt.want)
+		}
+	}
+}
+
+func TestMinRemovalsMatchesDampener(t *testing.T) {
+	r := rand.New(rand.NewPCG(3, 4))
+	for range 10000 {
+		rep := randomReport(r)
+		if got, want := MinRemovals(rep) <= 1, isSafeDampened(rep); got != want {
+			t.Fatalf("MinRemovals(%v) = %d; isSafeDampened = %t", rep, MinRemovals(rep), want)
+		}
+		if got, want := MinRemovals(rep) == 0, isSafe(rep); got != want {
+			t.Fatalf("MinRemovals(%v) = %d; isSafe = %t", rep, MinRemovals(rep), want)
+		}
+	}
+}
+
+func TestCountSafeReports(t *testing.T) {
+	reports := []report{
+		{7, 6, 4, 2, 1},
+		{1, 3, 2, 4, 5},
+		{1, 9, 2, 9, 3, 4},
+		{5, 5, 5, 5},
+	}
+	for k, want := range []int{1, 2, 3, 4} {
+		if got := countSafeReports(reports, k, DefaultPolicy); got != want {
+			t.Errorf("countSafeReports(%v, %d) = %d; want %d", reports, k, got, want)
+		}
+	}
+}
+
+func FuzzIsSafeDampened(f *testing.F) {
+	f.Add([]byte{7, 6, 4, 2, 1})
+	f.Add([]byte{1, 3, 2, 4, 5})
+	f.Add([]byte{8, 6, 4, 4, 1})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		rep := make(report, len(data))
+		for i, b := range data {
+			rep[i] = int(b % 16)
+		}
+		if got, want := isSafeDampened(rep), isSafeDampenedBrute(rep); got != want {
+			t.Errorf("isSafeDampened(%v) = %t; brute force says %t", rep, got, want)
+		}
+	})
+}
+
+func TestIsSafeDampenedAllocs(t *testing.T) {
+	rep := report{1, 2, 3, 4, 10, 5, 6, 7}
+	if n := testing.AllocsPerRun(100, func() { isSafeDampened(rep) }); n != 0 {
+		t.Errorf("isSafeDampened allocates %v times; want 0", n)
+	}
+}
+
+// longReport generates a long report with one bad level in the middle.
+func longReport(n int) report {
+	rep := make(report, n)
+	for i := range rep {
+		rep[i] = 2 * i
+	}
+	rep[n/2] += 100
+	return rep
+}
+
+func BenchmarkIsSafeDampened(b *testing.B) {
+	for _, n := range []int{10, 1000, 100000} {
+		rep := longReport(n)
+		b.Run(fmt.Sprintf("linear/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				isSafeDampened(rep)
+			}
+		})
+		if n > 1000 {
+			// Too slow to be worth comparing.
+			continue
+		}
+		b.Run(fmt.Sprintf("brute/%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				isSafeDampenedBrute(rep)
+			}
+		})
+	}
+}
+
+func TestSolver(t *testing.T) {
+	data, err := os.ReadFile("testdata/example.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &solver{policy: DefaultPolicy}
+	reports, err := s.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if got, err := s.Part1(reports); err != nil || got != 2 {
+		t.Errorf("Part1 = %d, %v; want 2", got, err)
+	}
+	if got, err := s.Part2(reports); err != nil || got != 4 {
+		t.Errorf("Part2 = %d, %v; want 4", got, err)
+	}
+}
+
+func TestSolverBlankLine(t *testing.T) {
+	s := &solver{policy: DefaultPolicy}
+	reports, err := s.Parse([]byte("7 6 4 2 1\n\n1 2 7 8 9\n"))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if got, err := s.Part1(reports); err != nil || got != 1 {
+		t.Errorf("Part1 = %d, %v; want 1", got, err)
+	}
+}
+
+func BenchmarkSolver(b *testing.B) {
+	puzzletest.Benchmark(b, &solver{policy: DefaultPolicy}, "../data/day2.txt")
+}
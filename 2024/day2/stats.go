@@ -0,0 +1,92 @@
+package day2
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// Stats summarizes the safety of a set of reports.
+type Stats struct {
+	Reports  int `json:"reports"`
+	Safe     int `json:"safe"`
+	Dampened int `json:"dampened"`
+	Unsafe   int `json:"unsafe"`
+	// Lengths counts the reports of each length.
+	Lengths map[int]int `json:"lengths"`
+	// Steps counts the differences of each size between adjacent levels.
+	Steps map[int]int `json:"steps"`
+	// Problems counts why reports are unsafe without the Problem Dampener,
+	// by their first unsafe pair of levels.
+	Problems map[Problem]int `json:"problems"`
+	// CommonProblem is the most common problem, if any reports are unsafe.
+	CommonProblem Problem `json:"common_problem,omitempty"`
+}
+
+// MarshalText encodes a problem as its name.
+func (p Problem) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// computeStats summarizes the safety of reports under a policy.
+func computeStats(reports []report, p SafetyPolicy) Stats {
+	s := Stats{
+		Reports:  len(reports),
+		Lengths:  make(map[int]int),
+		Steps:    make(map[int]int),
+		Problems: make(map[Problem]int),
+	}
+	for _, r := range reports {
+		s.Lengths[len(r)]++
+		for i := 0; i+1 < len(r); i++ {
+			diff := r[i+1] - r[i]
+			s.Steps[max(diff, -diff)]++
+		}
+
+		v := p.Explain(r)
+		switch {
+		case v.Safe():
+			s.Safe++
+			continue
+		case v.Fix >= 0:
+			s.Dampened++
+		default:
+			s.Unsafe++
+		}
+		s.Problems[v.Problem]++
+		if n := s.Problems[v.Problem]; n > s.Problems[s.CommonProblem] ||
+			(n == s.Problems[s.CommonProblem] && v.Problem < s.CommonProblem) {
+			s.CommonProblem = v.Problem
+		}
+	}
+	return s
+}
+
+// String formats the statistics as tables.
+func (s Stats) String() string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "reports\t%d\n", s.Reports)
+	fmt.Fprintf(w, "safe\t%d\n", s.Safe)
+	fmt.Fprintf(w, "safe when dampened\t%d\n", s.Dampened)
+	fmt.Fprintf(w, "unsafe\t%d\n", s.Unsafe)
+	if s.Safe < s.Reports {
+		fmt.Fprintf(w, "most common problem\t%v\n", s.CommonProblem)
+	}
+	writeHistogram(w, "length", "reports", s.Lengths)
+	writeHistogram(w, "step", "count", s.Steps)
+	w.Flush()
+	return b.String()
+}
+
+// writeHistogram writes a table of counts, in order of their keys.
+func writeHistogram(w io.Writer, key, count string, counts map[int]int) {
+	// A line without tabs ends the previous table.
+	fmt.Fprintf(w, "\n%s\t%s\n", key, count)
+	for _, k := range slices.Sorted(maps.Keys(counts)) {
+		fmt.Fprintf(w, "%d\t%d\n", k, counts[k])
+	}
+}
@@ -0,0 +1,73 @@
+package day2
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestComputeStats(t *testing.T) {
+	data, err := os.ReadFile("testdata/example.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	reports, err := readReports(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := computeStats(reports, DefaultPolicy)
+	want := Stats{
+		Reports:       6,
+		Safe:          2,
+		Dampened:      2,
+		Unsafe:        2,
+		Lengths:       map[int]int{5: 6},
+		Steps:         map[int]int{0: 1, 1: 10, 2: 9, 3: 2, 4: 1, 5: 1},
+		Problems:      map[Problem]int{TrendFlip: 1, StepTooSmall: 1, StepTooLarge: 2},
+		CommonProblem: StepTooLarge,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("computeStats(example) = %#v; want %#v", got, want)
+	}
+	if n := got.Safe + got.Dampened; n != countSafeReports(reports, 1, DefaultPolicy) {
+		t.Errorf("computeStats(example) has %d safe and dampened reports; countSafeReports says %d",
+			n, countSafeReports(reports, 1, DefaultPolicy))
+	}
+}
+
+func TestStatsOutput(t *testing.T) {
+	s := computeStats([]report{{1, 2, 3}, {3, 3}, {1, 9, 17}, {1, 5, 6}}, DefaultPolicy)
+
+	wantText := "" +
+		"reports              4\n" +
+		"safe                 1\n" +
+		"safe when dampened   2\n" +
+		"unsafe               1\n" +
+		"most common problem  step too large\n" +
+		"\n" +
+		"length  reports\n" +
+		"2       1\n" +
+		"3       3\n" +
+		"\n" +
+		"step  count\n" +
+		"0     1\n" +
+		"1     3\n" +
+		"4     1\n" +
+		"8     2\n"
+	if got := s.String(); got != wantText {
+		t.Errorf("Stats.String() =\n%s\nwant\n%s", got, wantText)
+	}
+
+	text, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantJSON := `{"reports":4,"safe":1,"dampened":2,"unsafe":1,` +
+		`"lengths":{"2":1,"3":3},"steps":{"0":1,"1":3,"4":1,"8":2},` +
+		`"problems":{"step too large":2,"step too small":1},"common_problem":"step too large"}`
+	if string(text) != wantJSON {
+		t.Errorf("json.Marshal(stats) = %s; want %s", text, wantJSON)
+	}
+}
@@ -1,16 +1,20 @@
-package main
+package day3
 
 import (
 	"bytes"
 	"fmt"
-	"log"
-	"os"
 	"regexp"
 	"strconv"
 	"strings"
+
+	"alger.au/aoc/puzzle"
 )
 
-const dataPath = "../data/day3.txt"
+const dataPath = "2024/data/day3.txt"
+
+func init() {
+	puzzle.Register(2024, 3, dataPath, solver{})
+}
 
 // findMuls finds all the mul(x,y) operations in corrupted memory data.
 func findMuls(data []byte) [][]byte {
@@ -79,23 +83,30 @@ func evaluateMulsDosDonts(muls [][]byte) (int, error) {
 	return total, nil
 }
 
-func main() {
-	data, err := os.ReadFile(dataPath)
-	if err != nil {
-		log.Fatalf("reading data: %v", err)
-	}
+// solver solves day 3.
+type solver struct{}
+
+// Parse returns the corrupted memory as-is; the parts scan it differently.
+func (solver) Parse(data []byte) ([]byte, error) {
+	return data, nil
+}
 
+// Part1 sums the uncorrupted muls.
+func (solver) Part1(data []byte) (puzzle.Answer, error) {
 	muls := findMuls(data)
 	total, err := evaluateMuls(muls)
 	if err != nil {
-		log.Fatalf("evaluating: %v", err)
+		return 0, fmt.Errorf("evaluating: %w", err)
 	}
-	fmt.Printf("1: %d\n", total)
+	return puzzle.Answer(total), nil
+}
 
-	muls = findUncorrupted(data)
-	total, err = evaluateMulsDosDonts(muls)
+// Part2 sums the uncorrupted muls, respecting dos and don'ts.
+func (solver) Part2(data []byte) (puzzle.Answer, error) {
+	muls := findUncorrupted(data)
+	total, err := evaluateMulsDosDonts(muls)
 	if err != nil {
-		log.Fatalf("evaluating: %v", err)
+		return 0, fmt.Errorf("evaluating: %w", err)
 	}
-	fmt.Printf("2: %d\n", total)
+	return puzzle.Answer(total), nil
 }
@@ -0,0 +1,89 @@
+package day3
+
+import (
+	"os"
+	"testing"
+
+	"alger.au/aoc/puzzle/puzzletest"
+)
+
+func TestEvaluateMuls(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want int
+	}{
+		{"example", "xmul(2,4)%&mul[3,7]!@^do_not_mul(5,5)+mul(32,64]then(mul(11,8)mul(8,5))", 161},
+		{"empty", "", 0},
+		{"too many digits", "mul(1234,5)", 0},
+		{"spaces", "mul( 2,4)", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := evaluateMuls(findMuls([]byte(tt.data)))
+			if err != nil {
+				t.Fatalf("evaluateMuls(%q) returned error: %v", tt.data, err)
+			}
+			if got != tt.want {
+				t.Errorf("evaluateMuls(%q) = %d; want %d", tt.data, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateMulsDosDonts(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want int
+	}{
+		{"example", "xmul(2,4)&mul[3,7]!^don't()_mul(5,5)+mul(32,64](mul(11,8)undo()?mul(8,5))", 48},
+		{"enabled by default", "mul(2,3)", 6},
+		{"disabled", "don't()mul(2,3)", 0},
+		{"re-enabled", "don't()mul(2,3)do()mul(4,5)", 20},
+		{"repeated do", "do()do()mul(1,1)", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := evaluateMulsDosDonts(findUncorrupted([]byte(tt.data)))
+			if err != nil {
+				t.Fatalf("evaluateMulsDosDonts(%q) returned error: %v", tt.data, err)
+			}
+			if got != tt.want {
+				t.Errorf("evaluateMulsDosDonts(%q) = %d; want %d", tt.data, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSolver(t *testing.T) {
+	tests := []struct {
+		path string
+		part int
+		want int
+	}{
+		{"testdata/example1.txt", 1, 161},
+		{"testdata/example2.txt", 2, 48},
+	}
+	for _, tt := range tests {
+		data, err := os.ReadFile(tt.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		memory, err := solver{}.Parse(data)
+		if err != nil {
+			t.Fatalf("Parse(%s): %v", tt.path, err)
+		}
+		part := solver{}.Part1
+		if tt.part == 2 {
+			part = solver{}.Part2
+		}
+		if got, err := part(memory); err != nil || int(got) != tt.want {
+			t.Errorf("Part%d(%s) = %d, %v; want %d", tt.part, tt.path, got, err, tt.want)
+		}
+	}
+}
+
+func BenchmarkSolver(b *testing.B) {
+	puzzletest.Benchmark(b, solver{}, "../data/day3.txt")
+}
@@ -1,13 +1,15 @@
-package main
+package day4
 
 import (
-	"fmt"
-	"log"
-	"os"
-	"strings"
+	"alger.au/aoc/grid"
+	"alger.au/aoc/puzzle"
 )
 
-const dataPath = "../data/day4.txt"
+const dataPath = "2024/data/day4.txt"
+
+func init() {
+	puzzle.Register(2024, 4, dataPath, solver{})
+}
 
 // Word stores metadata for a word.
 type Word struct {
@@ -37,159 +39,82 @@ func reversed(s string) string {
 	return string(r)
 }
 
-// countHorizontal counts horizontal word occurrences.
-func countHorizontal(lines []string, w Word) int {
-	n := 0
-	rw := reversed(w.Word)
-	for _, line := range lines {
-		for x, l := range line {
-			if l != w.Start && l != w.End {
-				continue
-			}
-			if x+w.Len > len(line) {
-				break
-			}
-			maybe := line[x : x+w.Len]
-			if maybe == w.Word || maybe == rw {
-				n += 1
-			}
-		}
-	}
-	return n
-}
-
-// getVerticalWord gets a word that goes over multiple lines.
-func getVerticalWord(lines []string, x, y, wlen, offset int) string {
-	w := make([]byte, wlen, wlen)
+// getWord gets the word of length wlen starting at p and going in direction d.
+// It returns false if the word would go off the grid.
+func getWord(g *grid.Grid, p, d grid.Point, wlen int) (string, bool) {
+	w := make([]byte, wlen)
 	for i := range wlen {
-		w[i] = lines[y+i][x+offset*i]
-	}
-	return string(w)
-}
-
-// countVertical counts vertical word occurrences.
-func countVertical(lines []string, w Word) int {
-	n := 0
-	rw := reversed(w.Word)
-
-	for y, line := range lines {
-		for x, l := range line {
-			if l != w.Start && l != w.End {
-				continue
-			}
-			if y+w.Len > len(lines) {
-				break
-			}
-			maybe := getVerticalWord(lines, x, y, w.Len, 0)
-			if maybe == w.Word || maybe == rw {
-				n += 1
-			}
+		v, ok := g.At(p.Add(d.Scale(i)))
+		if !ok {
+			return "", false
 		}
+		w[i] = v
 	}
-	return n
+	return string(w), true
 }
 
-// countForwardDiagonal counts word occurrences that are vertical with a negative slope.
-func countForwardDiagonal(lines []string, w Word) int {
+// countDirection counts word occurrences in direction d, forwards or backwards.
+func countDirection(g *grid.Grid, w Word, d grid.Point) int {
 	n := 0
 	rw := reversed(w.Word)
 
-	for y, line := range lines {
-		for x, l := range line {
-			if l != w.Start && l != w.End {
-				continue
-			}
-			if y+w.Len > len(lines) || x+w.Len > len(line) {
-				continue
-			}
-			maybe := getVerticalWord(lines, x, y, w.Len, 1)
-			if maybe == w.Word || maybe == rw {
-				n += 1
-			}
+	for p, l := range g.All() {
+		if rune(l) != w.Start && rune(l) != w.End {
+			continue
+		}
+		maybe, ok := getWord(g, p, d, w.Len)
+		if ok && (maybe == w.Word || maybe == rw) {
+			n += 1
 		}
 	}
 	return n
 }
 
-// countBackwardDiagonal counts word occurrences that are vertical with a positive slope.
-func countBackwardDiagonal(lines []string, w Word) int {
+// countX counts word occurrences that appear in an X shape.
+func countX(g *grid.Grid, w Word) int {
 	n := 0
 	rw := reversed(w.Word)
 
-	for y, line := range lines {
-		for x, l := range line {
-			if l != w.Start && l != w.End {
-				continue
-			}
-			if y+w.Len > len(lines) || x-w.Len < -1 {
-				continue
-			}
-			maybe := getVerticalWord(lines, x, y, w.Len, -1)
-			if maybe == w.Word || maybe == rw {
-				n += 1
-			}
+	for p, l := range g.All() {
+		if rune(l) != w.Start && rune(l) != w.End {
+			continue
+		}
+		maybe, ok := getWord(g, p, grid.DownRight, w.Len)
+		if !ok || (maybe != w.Word && maybe != rw) {
+			continue
+		}
+		maybe, ok = getWord(g, p.Add(grid.Right.Scale(w.Len-1)), grid.DownLeft, w.Len)
+		if !ok || (maybe != w.Word && maybe != rw) {
+			continue
 		}
+		n += 1
 	}
 	return n
 }
 
-// cleanLines splits lines and removes empty ones.
-func cleanLines(data string) []string {
-	lines := strings.Split(data, "\n")
-
-	// Strip empty lines.
-	for len(lines[len(lines)-1]) == 0 {
-		lines = lines[:len(lines)-1]
-	}
-	return lines
+// countWords counts how many words occur in the grid.
+func countWords(g *grid.Grid, w Word) int {
+	// Counting backwards words too means we only need half the directions.
+	return (countDirection(g, w, grid.Right) +
+		countDirection(g, w, grid.Down) +
+		countDirection(g, w, grid.DownRight) +
+		countDirection(g, w, grid.DownLeft))
 }
 
-// countX counts word occurrences that appear in an X shape.
-func countX(data string, w Word) int {
-	lines := cleanLines(data)
-	n := 0
-	rw := reversed(w.Word)
+// solver solves day 4.
+type solver struct{}
 
-	for y, line := range lines {
-		for x, l := range line {
-			if l != w.Start && l != w.End {
-				continue
-			}
-			if y+w.Len > len(lines) || x+w.Len > len(line) {
-				continue
-			}
-			maybe := getVerticalWord(lines, x, y, w.Len, 1)
-			if maybe != w.Word && maybe != rw {
-				continue
-			}
-			maybe = getVerticalWord(lines, x+w.Len-1, y, w.Len, -1)
-			if maybe != w.Word && maybe != rw {
-				continue
-			}
-			n += 1
-		}
-	}
-	return n
+// Parse parses the word search into a grid.
+func (solver) Parse(data []byte) (*grid.Grid, error) {
+	return grid.Parse(data)
 }
 
-// countWords counts how many words occur in the data.
-func countWords(data string, w Word) int {
-	lines := cleanLines(data)
-	return (countHorizontal(lines, w) +
-		countVertical(lines, w) +
-		countForwardDiagonal(lines, w) +
-		countBackwardDiagonal(lines, w))
+// Part1 counts occurrences of XMAS.
+func (solver) Part1(g *grid.Grid) (puzzle.Answer, error) {
+	return puzzle.Answer(countWords(g, makeWord("XMAS"))), nil
 }
 
-func main() {
-	data, err := os.ReadFile(dataPath)
-	if err != nil {
-		log.Fatalf("reading data: %v", err)
-	}
-
-	n := countWords(string(data), makeWord("XMAS"))
-	fmt.Printf("1: %d\n", n)
-
-	n = countX(string(data), makeWord("MAS"))
-	fmt.Printf("2: %d\n", n)
+// Part2 counts occurrences of MAS in an X shape.
+func (solver) Part2(g *grid.Grid) (puzzle.Answer, error) {
+	return puzzle.Answer(countX(g, makeWord("MAS"))), nil
 }
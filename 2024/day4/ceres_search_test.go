@@ -0,0 +1,84 @@
+package day4
+
+import (
+	"os"
+	"testing"
+
+	"alger.au/aoc/grid"
+	"alger.au/aoc/puzzle/puzzletest"
+)
+
+// mustParse parses a grid.
+func mustParse(t *testing.T, data string) *grid.Grid {
+	t.Helper()
+	g, err := grid.Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("grid.Parse(%q): %v", data, err)
+	}
+	return g
+}
+
+func TestCountWords(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want int
+	}{
+		{"horizontal", "XMAS\n....", 1},
+		{"backwards", "SAMX", 1},
+		{"vertical", "X\nM\nA\nS", 1},
+		{"forward diagonal", "X...\n.M..\n..A.\n...S", 1},
+		{"backward diagonal", "...X\n..M.\n.A..\nS...", 1},
+		{"none", "XMA.\n....", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := countWords(mustParse(t, tt.data), makeWord("XMAS")); got != tt.want {
+				t.Errorf("countWords(%q) = %d; want %d", tt.data, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountX(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want int
+	}{
+		{"forwards", "M.S\n.A.\nM.S", 1},
+		{"backwards", "S.S\n.A.\nM.M", 1},
+		{"mixed", "M.M\n.A.\nS.S", 1},
+		{"plus shape", ".M.\nMAS\n.S.", 0},
+		{"crossed out", "M.M\n.A.\nM.S", 0},
+		{"too small", "MA\nAS", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := countX(mustParse(t, tt.data), makeWord("MAS")); got != tt.want {
+				t.Errorf("countX(%q) = %d; want %d", tt.data, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSolver(t *testing.T) {
+	data, err := os.ReadFile("testdata/example.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := solver{}.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if got, err := (solver{}).Part1(g); err != nil || got != 18 {
+		t.Errorf("Part1 = %d, %v; want 18", got, err)
+	}
+	if got, err := (solver{}).Part2(g); err != nil || got != 9 {
+		t.Errorf("Part2 = %d, %v; want 9", got, err)
+	}
+}
+
+func BenchmarkSolver(b *testing.B) {
+	puzzletest.Benchmark(b, solver{}, "../data/day4.txt")
+}
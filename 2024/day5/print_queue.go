@@ -1,49 +1,60 @@
-package main
+package day5
 
 import (
 	"fmt"
-	"log"
-	"os"
-	"regexp"
 	"strconv"
 	"strings"
+
+	"alger.au/aoc/input"
+	"alger.au/aoc/puzzle"
 )
 
-const dataPath = "../data/day5.txt"
+const dataPath = "2024/data/day5.txt"
+
+func init() {
+	puzzle.Register(2024, 5, dataPath, solver{})
+}
 
 type stringPair struct {
 	fst string
 	snd string
 }
 
+// parseRule parses an ordering rule like 47|53.
+func parseRule(line string) (stringPair, error) {
+	a, b, ok := strings.Cut(strings.TrimSpace(line), "|")
+	if !ok {
+		return stringPair{}, fmt.Errorf("invalid rule: %s", line)
+	}
+	return stringPair{a, b}, nil
+}
+
+// parseOrdering parses a page ordering like 75,47,61.
+func parseOrdering(line string) ([]string, error) {
+	return strings.Split(strings.TrimSpace(line), ","), nil
+}
+
 // parse parses data into map of ordering rules and page orderings.
-func parse(data string) (map[stringPair]bool, [][]string, error) {
-	re := regexp.MustCompile("\\s+$")
-	lines := strings.Split(data, "\n")
-	iFinalGraph := 0
-	rules := make(map[stringPair]bool)
-	// Rules:
-	for i, line := range lines {
-		line = re.ReplaceAllString(line, "")
-		if line == "" {
-			iFinalGraph = i
-			break
-		}
+func parse(data []byte) (map[stringPair]bool, [][]string, error) {
+	// Up to the first fully-blank line is the ordering graph.
+	// After that is the page orderings.
+	sections := input.Sections(data)
+	if len(sections) != 2 {
+		return nil, nil, fmt.Errorf("expected 2 sections; got %d", len(sections))
+	}
 
-		a, b, ok := strings.Cut(line, "|")
-		if !ok {
-			return nil, nil, fmt.Errorf("invalid rule: %s", line)
-		}
-		rules[stringPair{a, b}] = true
+	pairs, err := input.MapSection(sections[0], parseRule)
+	if err != nil {
+		return nil, nil, err
 	}
-	// Orderings:
-	orderings := make([][]string, 0)
-	for i, line := range lines {
-		if i <= iFinalGraph {
-			continue
-		}
-		line = re.ReplaceAllString(line, "")
-		orderings = append(orderings, strings.Split(line, ","))
+	rules := make(map[stringPair]bool, len(pairs))
+	for _, p := range pairs {
+		rules[p] = true
+	}
+
+	orderings, err := input.MapSection(sections[1], parseOrdering)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	return rules, orderings, nil
@@ -63,7 +74,7 @@ func isValid(r map[stringPair]bool, o []string) (bool, error) {
 				// not ok!!
 				return false, nil
 			}
-			panic("how is this possible...")
+			return false, fmt.Errorf("no rule orders pages %s and %s", v1, v2)
 		}
 	}
 	return true, nil
@@ -86,7 +97,7 @@ func filterValid(r map[stringPair]bool, os [][]string, invert bool) ([][]string,
 
 // sortOrdering sorts an ordering by a set of rules.
 func sortOrdering(r map[stringPair]bool, o []string) ([]string, error) {
-	merge := func(a, b []string) []string {
+	merge := func(a, b []string) ([]string, error) {
 		c := make([]string, 0, len(a)+len(b))
 		for len(a) > 0 && len(b) > 0 {
 			cmpAB := r[stringPair{a[0], b[0]}]
@@ -103,12 +114,12 @@ func sortOrdering(r map[stringPair]bool, o []string) ([]string, error) {
 				b = b[1:]
 				continue
 			}
-			panic("Unreachable")
+			return nil, fmt.Errorf("no rule orders pages %s and %s", a[0], b[0])
 		}
 		if len(a) > 0 {
-			return append(c, a...)
+			return append(c, a...), nil
 		}
-		return append(c, b...)
+		return append(c, b...), nil
 	}
 
 	groups := make([][]string, 0, len(o))
@@ -130,7 +141,10 @@ func sortOrdering(r map[stringPair]bool, o []string) ([]string, error) {
 			}
 			a := groups[i]
 			b := groups[i+1]
-			c := merge(a, b)
+			c, err := merge(a, b)
+			if err != nil {
+				return nil, err
+			}
 			newGroups = append(newGroups, c)
 		}
 		groups = newGroups
@@ -138,61 +152,67 @@ func sortOrdering(r map[stringPair]bool, o []string) ([]string, error) {
 	return groups[0], nil
 }
 
-func main() {
-	data, err := os.ReadFile(dataPath)
-	if err != nil {
-		log.Fatalf("reading data: %v", err)
+// sumMiddles adds up the middle pages of page orderings.
+func sumMiddles(orderings [][]string) (int, error) {
+	total := 0
+	for _, ordering := range orderings {
+		s := ordering[len(ordering)/2]
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, fmt.Errorf("invalid int: %s", s)
+		}
+		total += v
 	}
-	// Up to the first fully-blank line is the ordering graph.
-	// After that is the page orderings.
-	rules, pageOrderings, err := parse(string(data))
+	return total, nil
+}
+
+// printQueue holds the page ordering rules and the page orderings of each update.
+type printQueue struct {
+	rules     map[stringPair]bool
+	orderings [][]string
+}
+
+// solver solves day 5.
+type solver struct{}
+
+// Parse parses the rules and orderings.
+func (solver) Parse(data []byte) (printQueue, error) {
+	rules, orderings, err := parse(data)
 	if err != nil {
-		fmt.Printf("error parsing: %v\n", err)
-		return
+		return printQueue{}, err
 	}
+	return printQueue{rules, orderings}, nil
+}
 
+// Part1 adds up the middle pages of the valid orderings.
+func (solver) Part1(q printQueue) (puzzle.Answer, error) {
 	// Find all the valid orderings (so we can add up their middles).
-	validOrderings, err := filterValid(rules, pageOrderings, false)
+	validOrderings, err := filterValid(q.rules, q.orderings, false)
 	if err != nil {
-		fmt.Printf("error filtering: %v\n", err)
-		return
+		return 0, fmt.Errorf("filtering: %w", err)
 	}
 
-	// Add up the middle pages.
-	total := 0
-	for _, valid := range validOrderings {
-		s := valid[len(valid)/2]
-		v, err := strconv.Atoi(s)
-		if err != nil {
-			fmt.Printf("invalid int: %s", s)
-			return
-		}
-		total += v
-	}
-	fmt.Printf("1: %d\n", total)
+	total, err := sumMiddles(validOrderings)
+	return puzzle.Answer(total), err
+}
 
+// Part2 adds up the middle pages of the invalid orderings once they are sorted.
+func (solver) Part2(q printQueue) (puzzle.Answer, error) {
 	// For part two, we need the invalid ones instead.
-	// It's easier to redo the calculation than store the results.
-	invalidOrderings, err := filterValid(rules, pageOrderings, true)
+	invalidOrderings, err := filterValid(q.rules, q.orderings, true)
 	if err != nil {
-		fmt.Printf("error filtering: %v\n", err)
-		return
+		return 0, fmt.Errorf("filtering: %w", err)
 	}
 
-	total = 0
+	sorted := make([][]string, 0, len(invalidOrderings))
 	for _, ordering := range invalidOrderings {
-		ordering, err = sortOrdering(rules, ordering)
+		ordering, err = sortOrdering(q.rules, ordering)
 		if err != nil {
-			fmt.Printf("error sorting: %v\n", err)
-			return
+			return 0, fmt.Errorf("sorting: %w", err)
 		}
-		s := ordering[len(ordering)/2]
-		v, err := strconv.Atoi(s)
-		if err != nil {
-			fmt.Printf("invalid int: %s", s)
-			return
-		}
-		total += v
+		sorted = append(sorted, ordering)
 	}
-	fmt.Printf("2: %d\n", total)
+
+	total, err := sumMiddles(sorted)
+	return puzzle.Answer(total), err
 }
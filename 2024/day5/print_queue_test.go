@@ -0,0 +1,132 @@
+package day5
+
+import (
+	"os"
+	"slices"
+	"testing"
+
+	"alger.au/aoc/puzzle/puzzletest"
+)
+
+// readExample parses the example print queue.
+func readExample(t *testing.T) printQueue {
+	t.Helper()
+	data, err := os.ReadFile("testdata/example.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, err := solver{}.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	return q
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"no trailing newline", "47|53\n\n47,53"},
+		{"trailing newline", "47|53\n\n47,53\n"},
+		{"crlf", "47|53\r\n\r\n47,53\r\n"},
+		{"trailing whitespace", "47|53 \n  \n47,53\t\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, orderings, err := parse([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("parse(%q) returned error: %v", tt.data, err)
+			}
+			if !rules[stringPair{"47", "53"}] || len(rules) != 1 {
+				t.Errorf("parse(%q) rules = %v; want 47|53", tt.data, rules)
+			}
+			want := [][]string{{"47", "53"}}
+			if !slices.EqualFunc(orderings, want, slices.Equal) {
+				t.Errorf("parse(%q) orderings = %v; want %v", tt.data, orderings, want)
+			}
+		})
+	}
+}
+
+func TestFilterValid(t *testing.T) {
+	q := readExample(t)
+	tests := []struct {
+		invert bool
+		want   [][]string
+	}{
+		{false, [][]string{
+			{"75", "47", "61", "53", "29"},
+			{"97", "61", "53", "29", "13"},
+			{"75", "29", "13"},
+		}},
+		{true, [][]string{
+			{"75", "97", "47", "61", "53"},
+			{"61", "13", "29"},
+			{"97", "13", "75", "29", "47"},
+		}},
+	}
+	for _, tt := range tests {
+		got, err := filterValid(q.rules, q.orderings, tt.invert)
+		if err != nil {
+			t.Fatalf("filterValid(invert=%t) returned error: %v", tt.invert, err)
+		}
+		if !slices.EqualFunc(got, tt.want, slices.Equal) {
+			t.Errorf("filterValid(invert=%t) = %v; want %v", tt.invert, got, tt.want)
+		}
+	}
+}
+
+func TestSortOrdering(t *testing.T) {
+	q := readExample(t)
+	tests := []struct {
+		ordering []string
+		want     []string
+	}{
+		{[]string{"75", "97", "47", "61", "53"}, []string{"97", "75", "47", "61", "53"}},
+		{[]string{"61", "13", "29"}, []string{"61", "29", "13"}},
+		{[]string{"97", "13", "75", "29", "47"}, []string{"97", "75", "47", "29", "13"}},
+		// Already sorted orderings are unchanged.
+		{[]string{"75", "29", "13"}, []string{"75", "29", "13"}},
+		{[]string{"47"}, []string{"47"}},
+	}
+	for _, tt := range tests {
+		got, err := sortOrdering(q.rules, tt.ordering)
+		if err != nil {
+			t.Fatalf("sortOrdering(%v) returned error: %v", tt.ordering, err)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("sortOrdering(%v) = %v; want %v", tt.ordering, got, tt.want)
+		}
+	}
+}
+
+func TestUnorderedPages(t *testing.T) {
+	q, err := solver{}.Parse([]byte("47|53\n\n47,53,61\n61,47\n"))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if _, err := isValid(q.rules, q.orderings[0]); err == nil {
+		t.Errorf("isValid(%v) returned no error", q.orderings[0])
+	}
+	if _, err := sortOrdering(q.rules, q.orderings[1]); err == nil {
+		t.Errorf("sortOrdering(%v) returned no error", q.orderings[1])
+	}
+	if _, err := (solver{}).Part1(q); err == nil {
+		t.Errorf("Part1 returned no error")
+	}
+}
+
+func TestSolver(t *testing.T) {
+	q := readExample(t)
+	if got, err := (solver{}).Part1(q); err != nil || got != 143 {
+		t.Errorf("Part1 = %d, %v; want 143", got, err)
+	}
+	if got, err := (solver{}).Part2(q); err != nil || got != 123 {
+		t.Errorf("Part2 = %d, %v; want 123", got, err)
+	}
+}
+
+func BenchmarkSolver(b *testing.B) {
+	puzzletest.Benchmark(b, solver{}, "../data/day5.txt")
+}
@@ -1,44 +1,35 @@
-package main
+package day6
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
-	"os"
 	"slices"
 
-	"github.com/schollz/progressbar/v3"
+	"alger.au/aoc/grid"
+	"alger.au/aoc/puzzle"
 )
 
-const dataPath = "../data/day6.txt"
+const dataPath = "2024/data/day6.txt"
 
-// parse parses the grid data into a grid of bytes.
-func parse(data []byte) [][]byte {
-	rows := bytes.Split(data, []byte("\n"))
-	for i, row := range rows {
-		rows[i] = bytes.TrimSuffix(row, []byte("\r"))
-	}
-	return rows
-}
-
-// printGrid prints a grid to stdout.
-func printGrid(grid [][]byte) {
-	for _, row := range grid {
-		for _, v := range row {
-			fmt.Print(string(v))
-		}
-		fmt.Print("\n")
-	}
+func init() {
+	puzzle.Register(2024, 6, dataPath, &solver{})
 }
 
 type simulator struct {
 	// By caching the guard location we can avoid many lookups.
-	guardY      int
-	guardX      int
-	grid        [][]byte
+	guard       grid.Point
+	grid        *grid.Grid
 	guardStates [][][]byte
 }
 
+// guardDirections maps each guard to the direction she is facing.
+var guardDirections = map[byte]grid.Point{
+	'^': grid.Up,
+	'>': grid.Right,
+	'v': grid.Down,
+	'<': grid.Left,
+}
+
 // rotate rotates the guard.
 func rotate(guard byte) (byte, error) {
 	switch guard {
@@ -58,91 +49,64 @@ func rotate(guard byte) (byte, error) {
 // step mutates the grid to simulate the guard moving one step.
 // It returns whether the guard is still on the grid and whether the guard has looped.
 func (s *simulator) step() (bool, bool, error) {
-	dx, dy := 0, 0
-	guard := s.grid[s.guardY][s.guardX]
-	switch guard {
-	case '>':
-		dx = 1
-	case '<':
-		dx = -1
-	case '^':
-		dy = -1
-	case 'v':
-		dy = 1
-	default:
+	guard, _ := s.grid.At(s.guard)
+	d, ok := guardDirections[guard]
+	if !ok {
 		return false, false, fmt.Errorf("invalid guard: %v", guard)
 	}
 
-	nx, ny := s.guardX+dx, s.guardY+dy
-	if nx < len(s.grid[0]) && nx >= 0 && ny >= 0 && ny < len(s.grid) {
-		// Not moving off the grid.
-		to := s.grid[ny][nx]
-		switch to {
-		case '#':
-			// Blocked! Guard turns.
-			guard, err := rotate(guard)
-			if err != nil {
-				return false, false, err
-			}
-			s.grid[s.guardY][s.guardX] = guard
-		case '.', 'X':
-			// Clear! Guard walks forward.
-			// Has she been to the new location before?
-			gs := s.guardStates[ny][nx]
-			if slices.Index(gs, guard) >= 0 {
-				// She has!
-				return true, true, nil
-			}
-			// Move her forward and update the places and directions she's been.
-			s.grid[ny][nx] = guard
-			s.grid[s.guardY][s.guardX] = 'X'
-			s.guardStates[s.guardY][s.guardX] = append(s.guardStates[s.guardY][s.guardX], guard)
-			s.guardX, s.guardY = nx, ny
-		default:
-			return false, false, fmt.Errorf("invalid obstacle: %q", string(to))
-		}
-	} else {
+	next := s.guard.Add(d)
+	to, ok := s.grid.At(next)
+	if !ok {
 		// Moving off the grid.
-		s.grid[s.guardY][s.guardX] = 'X'
+		s.grid.Set(s.guard, 'X')
 		return true, false, nil
 	}
-	return false, false, nil
-}
 
-// findGuard finds the guard's coordinates in the grid.
-func findGuard(grid [][]byte) (int, int, error) {
-	for y, row := range grid {
-		for x, v := range row {
-			switch v {
-			case 'v', '>', '<', '^':
-				return x, y, nil
-			default:
-				continue
-			}
+	// Not moving off the grid.
+	switch to {
+	case '#':
+		// Blocked! Guard turns.
+		guard, err := rotate(guard)
+		if err != nil {
+			return false, false, err
+		}
+		s.grid.Set(s.guard, guard)
+	case '.', 'X':
+		// Clear! Guard walks forward.
+		// Has she been to the new location before?
+		gs := s.guardStates[next.Y][next.X]
+		if slices.Index(gs, guard) >= 0 {
+			// She has!
+			return true, true, nil
 		}
+		// Move her forward and update the places and directions she's been.
+		s.grid.Set(next, guard)
+		s.grid.Set(s.guard, 'X')
+		s.guardStates[s.guard.Y][s.guard.X] = append(s.guardStates[s.guard.Y][s.guard.X], guard)
+		s.guard = next
+	default:
+		return false, false, fmt.Errorf("invalid obstacle: %q", string(to))
 	}
-	return 0, 0, errors.New("guard is not in grid")
+	return false, false, nil
 }
 
-// countX counts the X's in a grid.
-func countX(grid [][]byte) int {
-	total := 0
-	for _, row := range grid {
-		for _, v := range row {
-			if v == 'X' {
-				total++
-			}
+// findGuard finds the guard's coordinates in the grid.
+func findGuard(g *grid.Grid) (grid.Point, error) {
+	for guard := range guardDirections {
+		if p, ok := g.Find(guard); ok {
+			return p, nil
 		}
 	}
-	return total
+	return grid.Point{}, errors.New("guard is not in grid")
 }
 
 // makeGuardStates makes a guardStates grid.
-func makeGuardStates(grid [][]byte) [][][]byte {
-	gs := make([][][]byte, 0, len(grid))
-	for _, row := range grid {
-		gsRow := make([][]byte, 0, len(row))
-		for _, _ = range row {
+func makeGuardStates(g *grid.Grid) [][][]byte {
+	gs := make([][][]byte, 0, g.Height)
+	for range g.Height {
+		gsRow := make([][]byte, 0, g.Width)
+		for range g.Width {
 			gsRow = append(gsRow, make([]byte, 0))
 		}
 		gs = append(gs, gsRow)
@@ -150,34 +114,24 @@ func makeGuardStates(grid [][]byte) [][][]byte {
 	return gs
 }
 
-// copyGrid deep-copies a grid.
-func copyGrid(grid [][]byte) [][]byte {
-	ng := make([][]byte, 0, len(grid))
-	for _, row := range grid {
-		ng = append(ng, slices.Clone(row))
-	}
-	return ng
-}
-
 var errTimeout error = errors.New("timeout")
 
 // simulateGuard simulates the movement of the guard and returns the number of locations she visits.
 // Also returns whether she looped.
 // This mutates the grid.
-func simulateGuard(grid [][]byte) (int, bool, error) {
-	x, y, err := findGuard(grid)
+func simulateGuard(g *grid.Grid) (int, bool, error) {
+	guard, err := findGuard(g)
 	if err != nil {
 		return 0, false, err
 	}
 	loop := true
 	s := simulator{
-		guardX:      x,
-		guardY:      y,
-		grid:        grid,
-		guardStates: makeGuardStates(grid),
+		guard:       guard,
+		grid:        g,
+		guardStates: makeGuardStates(g),
 	}
 	// The only way to exceed maxIter is an uncaught loop.
-	maxIter := len(grid) * len(grid[0]) * 4
+	maxIter := g.Width * g.Height * 4
 	nIter := 0
 	for nIter < maxIter {
 		nIter += 1
@@ -193,64 +147,80 @@ func simulateGuard(grid [][]byte) (int, bool, error) {
 	if maxIter == nIter {
 		return 0, false, errTimeout
 	}
-	return countX(grid), loop, nil
+	return g.Count('X'), loop, nil
 }
 
 // obstruct places an obstruction at the given location in the grid and returns a new grid.
-func obstruct(grid [][]byte, x, y int) [][]byte {
-	grid = copyGrid(grid)
-	grid[y][x] = '#'
-	return grid
+func obstruct(g *grid.Grid, p grid.Point) *grid.Grid {
+	g = g.Clone()
+	g.Set(p, '#')
+	return g
 }
 
 // countLoopObstructions counts how many obstructions can possibly cause the guard to loop.
-func countLoopObstructions(grid [][]byte) (int, error) {
+// If progress is not nil, it is called after each location is checked.
+func countLoopObstructions(g *grid.Grid, progress func(done, total int)) (int, error) {
 	// Brute-force solution.
-	bar := progressbar.Default(int64(len(grid) * len(grid[0])))
+	total := g.Width * g.Height
+	done := 0
 	n := 0
-	for y, row := range grid {
-		for x, v := range row {
-			bar.Add(1)
-			switch v {
-			case '.':
-				modifiedGrid := obstruct(grid, x, y)
-				_, loop, err := simulateGuard(modifiedGrid)
-				if err != nil && !errors.Is(err, errTimeout) {
-					return 0, err
-				}
-				if loop || errors.Is(err, errTimeout) {
-					// Sometimes the guard gets stuck in an infinite loop.
-					// This is of course a loop.
-					// I should fix this a different way, but it's easy enough
-					// to detect that it's not worth the time.
-					n++
-				}
-			default:
-				// Already something here.
-				continue
+	for p, v := range g.All() {
+		if progress != nil {
+			done++
+			progress(done, total)
+		}
+		switch v {
+		case '.':
+			modifiedGrid := obstruct(g, p)
+			_, loop, err := simulateGuard(modifiedGrid)
+			if err != nil && !errors.Is(err, errTimeout) {
+				return 0, err
 			}
+			if loop || errors.Is(err, errTimeout) {
+				// Sometimes the guard gets stuck in an infinite loop.
+				// This is of course a loop.
+				// I should fix this a different way, but it's easy enough
+				// to detect that it's not worth the time.
+				n++
+			}
+		default:
+			// Already something here.
+			continue
 		}
 	}
 	return n, nil
 }
 
-func main() {
-	data, err := os.ReadFile(dataPath)
-	if err != nil {
-		fmt.Printf("error reading data: %v\n", err)
-		return
-	}
+// solver solves day 6.
+type solver struct {
+	progress func(done, total int)
+}
 
-	grid := parse(data)
-	n, _, err := simulateGuard(copyGrid(grid))
+// SetProgress sets a function to report the progress of part 2.
+func (s *solver) SetProgress(f func(done, total int)) {
+	s.progress = f
+}
+
+// Parse parses the grid.
+func (solver) Parse(data []byte) (*grid.Grid, error) {
+	return grid.Parse(data)
+}
+
+// Part1 counts the locations the guard visits.
+func (solver) Part1(g *grid.Grid) (puzzle.Answer, error) {
+	// simulateGuard mutates the grid, so simulate on a copy.
+	n, _, err := simulateGuard(g.Clone())
 	if err != nil {
-		fmt.Printf("error simulating guard: %v\n", err)
+		return 0, fmt.Errorf("simulating guard: %w", err)
 	}
-	fmt.Printf("1: %d\n", n)
+	return puzzle.Answer(n), nil
+}
 
-	n, err = countLoopObstructions(grid)
+// Part2 counts the obstructions that make the guard loop.
+func (s *solver) Part2(g *grid.Grid) (puzzle.Answer, error) {
+	n, err := countLoopObstructions(g, s.progress)
 	if err != nil {
-		fmt.Printf("error obstructing guard: %v\n", err)
+		return 0, fmt.Errorf("obstructing guard: %w", err)
 	}
-	fmt.Printf("2: %d\n", n)
+	return puzzle.Answer(n), nil
 }
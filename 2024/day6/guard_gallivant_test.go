@@ -0,0 +1,88 @@
+package day6
+
+import (
+	"os"
+	"testing"
+
+	"alger.au/aoc/grid"
+	"alger.au/aoc/puzzle/puzzletest"
+)
+
+// readExample parses the example grid.
+func readExample(t *testing.T) *grid.Grid {
+	t.Helper()
+	data, err := os.ReadFile("testdata/example.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := grid.Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestSimulateGuard(t *testing.T) {
+	tests := []struct {
+		name     string
+		grid     string
+		want     int
+		wantLoop bool
+	}{
+		{"straight out", "...\n.^.\n...", 2, false},
+		{"turns right", ".#.\n.^.\n...", 2, false},
+		{"loop", ".#....\n.....#\n......\n#.....\n....#.\n.^....", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := grid.Parse([]byte(tt.grid))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, loop, err := simulateGuard(g)
+			if err != nil {
+				t.Fatalf("simulateGuard returned error: %v", err)
+			}
+			if loop != tt.wantLoop {
+				t.Errorf("simulateGuard loop = %t; want %t", loop, tt.wantLoop)
+			}
+			if !loop && got != tt.want {
+				t.Errorf("simulateGuard = %d; want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountLoopObstructions(t *testing.T) {
+	g := readExample(t)
+	calls, last := 0, 0
+	got, err := countLoopObstructions(g, func(done, total int) {
+		calls++
+		if done == total {
+			last = done
+		}
+	})
+	if err != nil {
+		t.Fatalf("countLoopObstructions returned error: %v", err)
+	}
+	if got != 6 {
+		t.Errorf("countLoopObstructions = %d; want 6", got)
+	}
+	if cells := g.Width * g.Height; calls != cells || last != cells {
+		t.Errorf("countLoopObstructions reported progress %d times, finishing at %d; want %d", calls, last, cells)
+	}
+}
+
+func TestSolver(t *testing.T) {
+	g := readExample(t)
+	if got, err := (solver{}).Part1(g); err != nil || got != 41 {
+		t.Errorf("Part1 = %d, %v; want 41", got, err)
+	}
+	if got, err := (&solver{}).Part2(g); err != nil || got != 6 {
+		t.Errorf("Part2 = %d, %v; want 6", got, err)
+	}
+}
+
+func BenchmarkSolver(b *testing.B) {
+	puzzletest.Benchmark(b, &solver{}, "../data/day6.txt")
+}
@@ -1,15 +1,21 @@
-package main
+package day7
 
 import (
 	"fmt"
-	"os"
 	"slices"
 	"strconv"
 	"strings"
+
+	"alger.au/aoc/input"
+	"alger.au/aoc/puzzle"
 )
 
 // dataPath is the path to the data.
-const dataPath string = "../data/day7.txt"
+const dataPath string = "2024/data/day7.txt"
+
+func init() {
+	puzzle.Register(2024, 7, dataPath, solver{})
+}
 
 // equation represents a value and the inputs that may potentially add to it.
 type equation struct {
@@ -19,45 +25,34 @@ type equation struct {
 
 // parseLine parses a line of calibration equations.
 func parseLine(l string) (equation, error) {
-	l = strings.TrimSuffix(l, "\r")
-	lhs, rhs, ok := strings.Cut(l, ": ")
-	if !ok {
+	lhs, rhs, ok := strings.Cut(l, ":")
+	if !ok || len(input.Fields(lhs)) != 1 {
 		return equation{}, fmt.Errorf("invalid equation: %s", l)
 	}
-	value, err := strconv.Atoi(lhs)
+	// Blank out the colon so that error columns line up with the line.
+	vals, err := input.Ints(lhs + " " + rhs)
 	if err != nil {
-		return equation{}, fmt.Errorf("invalid value: %s", lhs)
+		return equation{}, err
 	}
-
-	inputStrings := strings.Split(rhs, " ")
-	var inputs []int
-	for _, s := range inputStrings {
-		i, err := strconv.Atoi(s)
-		if err != nil {
-			return equation{}, fmt.Errorf("invalid value: %s", s)
-		}
-		inputs = append(inputs, i)
+	if len(vals) < 2 {
+		return equation{}, fmt.Errorf("equation has no inputs: %s", l)
 	}
 
 	return equation{
-		value:  value,
-		inputs: inputs,
+		value:  vals[0],
+		inputs: vals[1:],
 	}, nil
 }
 
 // parse parses a set of calibration equations.
 func parse(data []byte) ([]*equation, error) {
-	str := string(data)
-	lines := strings.Split(str, "\n")
-	var eqs []*equation
-	for _, l := range lines {
+	return input.Map(input.Lines(data), func(l string) (*equation, error) {
 		eq, err := parseLine(l)
 		if err != nil {
-			return nil, fmt.Errorf("parsing line: %w", err)
+			return nil, err
 		}
-		eqs = append(eqs, &eq)
-	}
-	return eqs, nil
+		return &eq, nil
+	})
 }
 
 // concat concatenates numbers.
@@ -95,32 +90,32 @@ func validEquation(eq *equation, allowConcat bool) bool {
 	return slices.Contains(all, eq.value)
 }
 
-func main() {
-	data, err := os.ReadFile(dataPath)
-	if err != nil {
-		fmt.Printf("error reading file: %v\n", err)
-		return
-	}
-
-	eqs, err := parse(data)
-	if err != nil {
-		fmt.Printf("error parsing file: %v\n", err)
-		return
-	}
-
+// calibrate sums the values of the valid equations.
+// allowConcat indicates whether the elephants have revealed their concatenation operator.
+func calibrate(eqs []*equation, allowConcat bool) int {
 	total := 0
 	for _, eq := range eqs {
-		if validEquation(eq, false) {
+		if validEquation(eq, allowConcat) {
 			total += eq.value
 		}
 	}
-	fmt.Printf("1: %d\n", total)
+	return total
+}
 
-	total = 0
-	for _, eq := range eqs {
-		if validEquation(eq, true) {
-			total += eq.value
-		}
-	}
-	fmt.Printf("2: %d\n", total)
+// solver solves day 7.
+type solver struct{}
+
+// Parse parses the calibration equations.
+func (solver) Parse(data []byte) ([]*equation, error) {
+	return parse(data)
+}
+
+// Part1 finds the total calibration result using + and *.
+func (solver) Part1(eqs []*equation) (puzzle.Answer, error) {
+	return puzzle.Answer(calibrate(eqs, false)), nil
+}
+
+// Part2 finds the total calibration result using +, * and ||.
+func (solver) Part2(eqs []*equation) (puzzle.Answer, error) {
+	return puzzle.Answer(calibrate(eqs, true)), nil
 }
@@ -1,6 +1,7 @@
 """Helping the bridge engineers with Python."""
 
 import dataclasses
+import sys
 
 _DATA_PATH = '../data/day7.txt'
 
@@ -45,7 +46,8 @@ def valid_equation(equation: Equation, allow_concat: bool = False) -> bool:
 
 
 def main():
-    with open(_DATA_PATH) as f:
+    path = sys.argv[1] if len(sys.argv) > 1 else _DATA_PATH
+    with open(path) as f:
         data = f.read()
 
     equations = parse(data)
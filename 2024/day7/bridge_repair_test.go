@@ -0,0 +1,105 @@
+package day7
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"alger.au/aoc/input"
+	"alger.au/aoc/puzzle/puzzletest"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want int
+	}{
+		{"no trailing newline", "190: 10 19\n83: 17 5", 2},
+		{"trailing newline", "190: 10 19\n83: 17 5\n", 2},
+		{"crlf", "190: 10 19\r\n83: 17 5\r\n\r\n", 2},
+		{"extra whitespace", "190:  10\t19", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eqs, err := parse([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("parse(%q) returned error: %v", tt.data, err)
+			}
+			if len(eqs) != tt.want {
+				t.Errorf("parse(%q) returned %d equations; want %d", tt.data, len(eqs), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrorPosition(t *testing.T) {
+	_, err := parse([]byte("190: 10 19\n83: 17 x5\n"))
+	var e *input.Error
+	if !errors.As(err, &e) {
+		t.Fatalf("parse returned %v; want an *input.Error", err)
+	}
+	if e.Line != 2 || e.Column != 8 {
+		t.Errorf("error at line %d, column %d; want line 2, column 8", e.Line, e.Column)
+	}
+}
+
+func TestValidEquation(t *testing.T) {
+	tests := []struct {
+		eq          equation
+		allowConcat bool
+		want        bool
+	}{
+		{equation{190, []int{10, 19}}, false, true},
+		{equation{3267, []int{81, 40, 27}}, false, true},
+		{equation{83, []int{17, 5}}, false, false},
+		{equation{156, []int{15, 6}}, false, false},
+		{equation{156, []int{15, 6}}, true, true},
+		{equation{7290, []int{6, 8, 6, 15}}, true, true},
+		{equation{192, []int{17, 8, 14}}, true, true},
+		{equation{21037, []int{9, 7, 18, 13}}, true, false},
+		{equation{292, []int{11, 6, 16, 20}}, false, true},
+		{equation{5, []int{5}}, false, true},
+	}
+	for _, tt := range tests {
+		if got := validEquation(&tt.eq, tt.allowConcat); got != tt.want {
+			t.Errorf("validEquation(%v, %t) = %t; want %t", tt.eq, tt.allowConcat, got, tt.want)
+		}
+	}
+}
+
+func TestConcat(t *testing.T) {
+	tests := []struct {
+		a, b, want int
+	}{
+		{12, 345, 12345},
+		{1, 0, 10},
+		{0, 7, 7},
+	}
+	for _, tt := range tests {
+		if got := concat(tt.a, tt.b); got != tt.want {
+			t.Errorf("concat(%d, %d) = %d; want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSolver(t *testing.T) {
+	data, err := os.ReadFile("testdata/example.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	eqs, err := solver{}.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if got, err := (solver{}).Part1(eqs); err != nil || got != 3749 {
+		t.Errorf("Part1 = %d, %v; want 3749", got, err)
+	}
+	if got, err := (solver{}).Part2(eqs); err != nil || got != 11387 {
+		t.Errorf("Part2 = %d, %v; want 11387", got, err)
+	}
+}
+
+func BenchmarkSolver(b *testing.B) {
+	puzzletest.Benchmark(b, solver{}, "../data/day7.txt")
+}
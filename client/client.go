@@ -0,0 +1,201 @@
+// Package client talks to the Advent of Code website.
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the Advent of Code website.
+const DefaultBaseURL = "https://adventofcode.com"
+
+// BaseURLEnv is the environment variable that overrides the base URL.
+const BaseURLEnv = "AOC_BASE_URL"
+
+// SessionEnv is the environment variable holding the session cookie.
+// If it is unset, the session cookie is read from the session file in the config directory.
+const SessionEnv = "AOC_SESSION"
+
+// userAgent identifies this tool to the website, as the Advent of Code maintainers request.
+const userAgent = "alger.au/aoc (https://github.com/MatthewJA/advent-of-code)"
+
+// ErrNoSession is returned when no session cookie is configured.
+var ErrNoSession = errors.New("no session cookie: set $AOC_SESSION or write it to the session file in the config directory")
+
+// Client fetches puzzle inputs, caching them locally and rate-limiting requests.
+type Client struct {
+	// BaseURL is the website to talk to, without a trailing slash.
+	BaseURL string
+	// Session is the value of the session cookie.
+	Session string
+	// CacheDir is where inputs and the time of the last request are stored.
+	// It should be kept separate for each website.
+	CacheDir string
+	// MinInterval is the minimum time between requests.
+	MinInterval time.Duration
+	// HTTPClient makes the requests.
+	HTTPClient *http.Client
+}
+
+// New makes a client for baseURL using the configured session and the user's cache directory.
+// If baseURL is empty, it is read from $AOC_BASE_URL, falling back to DefaultBaseURL.
+// Each website is cached separately, so a stand-in server never fills the cache for the real one.
+func New(baseURL string) (*Client, error) {
+	if baseURL == "" {
+		baseURL = os.Getenv(BaseURLEnv)
+	}
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid base URL: %q", baseURL)
+	}
+	session, err := readSession()
+	if err != nil {
+		return nil, err
+	}
+	cache, err := os.UserCacheDir()
+	if err != nil {
+		return nil, fmt.Errorf("finding cache directory: %w", err)
+	}
+	return &Client{
+		BaseURL:     strings.TrimSuffix(baseURL, "/"),
+		Session:     session,
+		CacheDir:    filepath.Join(cache, "aoc", strings.ReplaceAll(u.Host, ":", "_")),
+		MinInterval: 5 * time.Second,
+		HTTPClient:  &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+// ConfigDir is the directory holding the client configuration.
+func ConfigDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("finding config directory: %w", err)
+	}
+	return filepath.Join(dir, "aoc"), nil
+}
+
+// readSession reads the session cookie from $AOC_SESSION or the session file.
+func readSession() (string, error) {
+	if s := os.Getenv(SessionEnv); s != "" {
+		return s, nil
+	}
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	text, err := os.ReadFile(filepath.Join(dir, "session"))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", ErrNoSession
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading session: %w", err)
+	}
+	return strings.TrimSpace(string(text)), nil
+}
+
+// cachePath is the path of a cached input.
+func (c *Client) cachePath(year, day int) string {
+	return filepath.Join(c.CacheDir, fmt.Sprint(year), fmt.Sprintf("day%d.txt", day))
+}
+
+// Input gets the puzzle input for a day, from the cache if possible.
+func (c *Client) Input(ctx context.Context, year, day int) ([]byte, error) {
+	path := c.cachePath(year, day)
+	data, err := os.ReadFile(path)
+	if err == nil {
+		return data, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("reading cache: %w", err)
+	}
+
+	data, err = c.get(ctx, fmt.Sprintf("/%d/day/%d/input", year, day))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("caching input: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return nil, fmt.Errorf("caching input: %w", err)
+	}
+	return data, nil
+}
+
+// get makes a rate-limited GET request to a path on the website.
+func (c *Client) get(ctx context.Context, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.BaseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s: %s", path, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
+
+// do sends an authenticated, rate-limited request.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	if c.Session == "" {
+		return nil, ErrNoSession
+	}
+	if err := c.wait(req.Context()); err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	return c.HTTPClient.Do(req)
+}
+
+// wait blocks until MinInterval has passed since the last request.
+// The time of the last request is kept in the cache directory so that it
+// applies across runs.
+func (c *Client) wait(ctx context.Context) error {
+	stamp := filepath.Join(c.CacheDir, "last-request")
+	if text, err := os.ReadFile(stamp); err == nil {
+		last, err := time.Parse(time.RFC3339Nano, string(text))
+		if err != nil {
+			return fmt.Errorf("reading last request time: %w", err)
+		}
+		if d := c.MinInterval - time.Since(last); d > 0 {
+			t := time.NewTimer(d)
+			defer t.Stop()
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-t.C:
+			}
+		}
+	}
+
+	if err := os.MkdirAll(c.CacheDir, 0o755); err != nil {
+		return fmt.Errorf("recording request time: %w", err)
+	}
+	now := time.Now().Format(time.RFC3339Nano)
+	if err := os.WriteFile(stamp, []byte(now), 0o644); err != nil {
+		return fmt.Errorf("recording request time: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newTestClient makes a client for a stand-in server with an empty cache.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return &Client{
+		BaseURL:    srv.URL,
+		Session:    "s3cret",
+		CacheDir:   t.TempDir(),
+		HTTPClient: srv.Client(),
+	}
+}
+
+func TestInput(t *testing.T) {
+	requests := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/2024/day/6/input" {
+			http.NotFound(w, r)
+			return
+		}
+		if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "s3cret" {
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte("..#\n.^.\n"))
+	})
+
+	for range 2 {
+		got, err := c.Input(context.Background(), 2024, 6)
+		if err != nil {
+			t.Fatalf("Input returned error: %v", err)
+		}
+		if string(got) != "..#\n.^.\n" {
+			t.Errorf("Input = %q; want the served input", got)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("made %d requests; want 1 with the second served from the cache", requests)
+	}
+}
+
+func TestInputError(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+	if _, err := c.Input(context.Background(), 2024, 26); err == nil {
+		t.Errorf("Input for a missing day returned no error")
+	}
+	// Failed requests must not be cached.
+	if _, err := c.Input(context.Background(), 2024, 26); err == nil {
+		t.Errorf("second Input for a missing day returned no error")
+	}
+}
+
+func TestNoSession(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request without a session")
+	})
+	c.Session = ""
+	if _, err := c.Input(context.Background(), 2024, 1); err != ErrNoSession {
+		t.Errorf("Input without a session returned %v; want ErrNoSession", err)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("input"))
+	})
+	c.MinInterval = 100 * time.Millisecond
+
+	start := time.Now()
+	for day := range 3 {
+		if _, err := c.Input(context.Background(), 2024, day+1); err != nil {
+			t.Fatalf("Input returned error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 2*c.MinInterval {
+		t.Errorf("3 requests took %v; want at least %v", elapsed, 2*c.MinInterval)
+	}
+}
+
+func TestNewCachePerHost(t *testing.T) {
+	t.Setenv(SessionEnv, "s3cret")
+	t.Setenv(BaseURLEnv, "")
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	site, err := New("")
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	stub, err := New("http://127.0.0.1:8080/")
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	if filepath.Base(site.CacheDir) != "adventofcode.com" {
+		t.Errorf("cache directory for %s = %s; want it named after the host", site.BaseURL, site.CacheDir)
+	}
+	if site.CacheDir == stub.CacheDir || filepath.Dir(site.CacheDir) != filepath.Dir(stub.CacheDir) {
+		t.Errorf("cache directories %s and %s should be siblings", site.CacheDir, stub.CacheDir)
+	}
+
+	if _, err := New("not a url"); err == nil {
+		t.Errorf("New with an invalid base URL returned no error")
+	}
+}
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// Guess is a submitted answer and the website's verdict on it.
+type Guess struct {
+	Year    int       `json:"year"`
+	Day     int       `json:"day"`
+	Part    int       `json:"part"`
+	Answer  string    `json:"answer"`
+	Outcome Outcome   `json:"outcome"`
+	Time    time.Time `json:"time"`
+}
+
+// History is a record of every submitted answer.
+type History struct {
+	Guesses []Guess `json:"guesses"`
+}
+
+// HistoryPath is the default location of the submission history.
+func HistoryPath() (string, error) {
+	dir, err := ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "history.json"), nil
+}
+
+// ReadHistory reads the submission history from a file.
+// A missing file is an empty history.
+func ReadHistory(path string) (*History, error) {
+	text, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &History{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var h History
+	if err := json.Unmarshal(text, &h); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &h, nil
+}
+
+// Write writes the submission history to a file.
+func (h *History) Write(path string) error {
+	text, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(text, '\n'), 0o644)
+}
+
+// Add records a guess.
+func (h *History) Add(g Guess) {
+	h.Guesses = append(h.Guesses, g)
+}
+
+// Check determines whether an answer is worth submitting, given the previous guesses.
+// It returns an error if the part is already solved, the answer is known to be
+// wrong, or the answer is outside the bounds set by earlier too high or too low guesses.
+func (h *History) Check(year, day, part int, answer string) error {
+	n, numeric := parseInt(answer)
+	for _, g := range h.Guesses {
+		if g.Year != year || g.Day != day || g.Part != part {
+			continue
+		}
+		if g.Outcome == Correct {
+			return fmt.Errorf("already solved with %s", g.Answer)
+		}
+		if g.Answer == answer && g.Outcome.IsWrong() {
+			return fmt.Errorf("%s was already guessed and is %s", answer, g.Outcome)
+		}
+
+		bound, ok := parseInt(g.Answer)
+		if !ok || !numeric {
+			continue
+		}
+		if g.Outcome == TooHigh && n >= bound {
+			return fmt.Errorf("%s is too high: %s was already too high", answer, g.Answer)
+		}
+		if g.Outcome == TooLow && n <= bound {
+			return fmt.Errorf("%s is too low: %s was already too low", answer, g.Answer)
+		}
+	}
+	return nil
+}
+
+// parseInt parses an integer answer.
+func parseInt(s string) (int, bool) {
+	n, err := strconv.Atoi(s)
+	return n, err == nil
+}
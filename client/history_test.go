@@ -0,0 +1,58 @@
+package client
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestHistoryCheck(t *testing.T) {
+	h := &History{}
+	h.Add(Guess{Year: 2024, Day: 5, Part: 2, Answer: "6000", Outcome: TooHigh})
+	h.Add(Guess{Year: 2024, Day: 5, Part: 2, Answer: "5000", Outcome: TooLow})
+	h.Add(Guess{Year: 2024, Day: 5, Part: 2, Answer: "5500", Outcome: Wrong})
+	h.Add(Guess{Year: 2024, Day: 5, Part: 1, Answer: "4872", Outcome: Correct})
+
+	tests := []struct {
+		part    int
+		answer  string
+		wantErr bool
+	}{
+		{2, "5564", false},
+		{2, "5500", true},
+		{2, "6000", true},
+		{2, "7000", true},
+		{2, "5000", true},
+		{2, "12", true},
+		// Part 1 is already solved.
+		{1, "1234", true},
+	}
+	for _, tt := range tests {
+		err := h.Check(2024, 5, tt.part, tt.answer)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Check(part %d, %s) = %v; want error: %t", tt.part, tt.answer, err, tt.wantErr)
+		}
+	}
+	if err := h.Check(2024, 6, 2, "6000"); err != nil {
+		t.Errorf("Check for another day = %v; want no error", err)
+	}
+}
+
+func TestHistoryRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.json")
+	h, err := ReadHistory(path)
+	if err != nil {
+		t.Fatalf("ReadHistory of a missing file returned error: %v", err)
+	}
+	h.Add(Guess{Year: 2024, Day: 1, Part: 1, Answer: "11", Outcome: TooLow})
+	if err := h.Write(path); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Guesses) != 1 || got.Guesses[0].Outcome != TooLow || got.Guesses[0].Answer != "11" {
+		t.Errorf("ReadHistory = %+v; want the written guess", got.Guesses)
+	}
+}
@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Outcome is the website's verdict on a submitted answer.
+type Outcome int
+
+const (
+	// Unknown means the response could not be understood.
+	Unknown Outcome = iota
+	// Correct means the answer was right.
+	Correct
+	// TooHigh means the answer was wrong and too high.
+	TooHigh
+	// TooLow means the answer was wrong and too low.
+	TooLow
+	// Wrong means the answer was wrong, with no hint.
+	Wrong
+	// TooRecent means an answer was submitted too recently, so this one was not checked.
+	TooRecent
+	// WrongLevel means the part is already solved or not yet unlocked.
+	WrongLevel
+)
+
+var outcomeNames = []string{"unknown", "correct", "too high", "too low", "wrong", "too recent", "wrong level"}
+
+func (o Outcome) String() string {
+	if o < 0 || int(o) >= len(outcomeNames) {
+		return fmt.Sprintf("Outcome(%d)", int(o))
+	}
+	return outcomeNames[o]
+}
+
+// MarshalText encodes an outcome as its name.
+func (o Outcome) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+// UnmarshalText decodes an outcome from its name.
+func (o *Outcome) UnmarshalText(text []byte) error {
+	for i, name := range outcomeNames {
+		if name == string(text) {
+			*o = Outcome(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown outcome: %q", text)
+}
+
+// IsWrong reports whether the outcome means the answer was checked and is wrong.
+func (o Outcome) IsWrong() bool {
+	return o == TooHigh || o == TooLow || o == Wrong
+}
+
+// Verdict is the website's response to a submitted answer.
+type Verdict struct {
+	Outcome Outcome
+	// Message is the text of the response.
+	Message string
+}
+
+var (
+	articleRe = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagRe     = regexp.MustCompile(`<[^>]*>`)
+	spaceRe   = regexp.MustCompile(`\s+`)
+)
+
+// parseVerdict parses the HTML response to a submitted answer.
+func parseVerdict(body string) Verdict {
+	msg := body
+	if m := articleRe.FindStringSubmatch(body); m != nil {
+		msg = m[1]
+	}
+	msg = html.UnescapeString(tagRe.ReplaceAllString(msg, ""))
+	msg = strings.TrimSpace(spaceRe.ReplaceAllString(msg, " "))
+
+	v := Verdict{Message: msg}
+	switch {
+	case strings.Contains(msg, "That's the right answer"):
+		v.Outcome = Correct
+	case strings.Contains(msg, "your answer is too high"):
+		v.Outcome = TooHigh
+	case strings.Contains(msg, "your answer is too low"):
+		v.Outcome = TooLow
+	case strings.Contains(msg, "That's not the right answer"):
+		v.Outcome = Wrong
+	case strings.Contains(msg, "You gave an answer too recently"):
+		v.Outcome = TooRecent
+	case strings.Contains(msg, "You don't seem to be solving the right level"):
+		v.Outcome = WrongLevel
+	}
+	return v
+}
+
+// Submit submits an answer for one part of a day's puzzle.
+func (c *Client) Submit(ctx context.Context, year, day, part int, answer string) (Verdict, error) {
+	form := url.Values{
+		"level":  {fmt.Sprint(part)},
+		"answer": {answer},
+	}
+	path := fmt.Sprintf("/%d/day/%d/answer", year, day)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.BaseURL+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return Verdict{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return Verdict{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Verdict{}, fmt.Errorf("reading response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return Verdict{}, fmt.Errorf("POST %s: %s", path, resp.Status)
+	}
+	return parseVerdict(string(body)), nil
+}
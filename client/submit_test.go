@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+// page wraps a message the way the website does.
+func page(msg string) string {
+	return `<!DOCTYPE html><html><body><main><article><p>` + msg + `</p></article></main></body></html>`
+}
+
+func TestParseVerdict(t *testing.T) {
+	tests := []struct {
+		body string
+		want Outcome
+	}{
+		{page(`That's the right answer!  You are <span class="day-success">one gold star</span> closer.`), Correct},
+		{page(`That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data.`), TooHigh},
+		{page(`That's not the right answer; your answer is too low.`), TooLow},
+		{page(`That's not the right answer.  If you're stuck, make sure you're using the full input data.`), Wrong},
+		{page(`You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 37s left to wait.`), TooRecent},
+		{page(`You don't seem to be solving the right level.  Did you already complete it?`), WrongLevel},
+		{page(`Something else entirely.`), Unknown},
+	}
+	for _, tt := range tests {
+		if got := parseVerdict(tt.body); got.Outcome != tt.want {
+			t.Errorf("parseVerdict(%q) = %v; want %v", tt.body, got.Outcome, tt.want)
+		}
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2024/day/5/answer" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.FormValue("level") != "2" {
+			t.Errorf("submitted level %q; want 2", r.FormValue("level"))
+		}
+		if r.FormValue("answer") == "5564" {
+			w.Write([]byte(page(`That's the right answer!`)))
+			return
+		}
+		w.Write([]byte(page(`That's not the right answer; your answer is too low.`)))
+	})
+
+	tests := []struct {
+		answer string
+		want   Outcome
+	}{
+		{"5564", Correct},
+		{"12", TooLow},
+	}
+	for _, tt := range tests {
+		v, err := c.Submit(context.Background(), 2024, 5, 2, tt.answer)
+		if err != nil {
+			t.Fatalf("Submit(%s) returned error: %v", tt.answer, err)
+		}
+		if v.Outcome != tt.want {
+			t.Errorf("Submit(%s) = %v (%q); want %v", tt.answer, v.Outcome, v.Message, tt.want)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+	"text/tabwriter"
+	"time"
+
+	"alger.au/aoc/puzzle"
+)
+
+// benchmark is the cost of one phase of a solver.
+type benchmark struct {
+	NsPerOp     int64 `json:"ns_per_op"`
+	AllocsPerOp int64 `json:"allocs_per_op"`
+	BytesPerOp  int64 `json:"bytes_per_op"`
+}
+
+// dayBenchmarks are the costs of each phase of a day's solver.
+type dayBenchmarks struct {
+	Day   int       `json:"day"`
+	Parse benchmark `json:"parse"`
+	Part1 benchmark `json:"part1"`
+	Part2 benchmark `json:"part2"`
+}
+
+// benchReport is a saved set of benchmark results.
+type benchReport struct {
+	Year int             `json:"year"`
+	Days []dayBenchmarks `json:"days"`
+}
+
+// measure benchmarks f, stopping at the first error.
+func measure(f func() error) (benchmark, error) {
+	var err error
+	r := testing.Benchmark(func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			if err = f(); err != nil {
+				b.SkipNow()
+			}
+		}
+	})
+	if err != nil {
+		return benchmark{}, err
+	}
+	return benchmark{
+		NsPerOp:     r.NsPerOp(),
+		AllocsPerOp: r.AllocsPerOp(),
+		BytesPerOp:  r.AllocedBytesPerOp(),
+	}, nil
+}
+
+// benchDay benchmarks each phase of a day's solver on its data file.
+func benchDay(p puzzle.Puzzle) (dayBenchmarks, error) {
+	data, err := readInput(p, "")
+	if err != nil {
+		return dayBenchmarks{}, err
+	}
+	parsed, err := p.Parse(data)
+	if err != nil {
+		return dayBenchmarks{}, fmt.Errorf("parsing: %w", err)
+	}
+
+	d := dayBenchmarks{Day: p.Day}
+	d.Parse, err = measure(func() error {
+		_, err := p.Parse(data)
+		return err
+	})
+	if err != nil {
+		return dayBenchmarks{}, fmt.Errorf("parsing: %w", err)
+	}
+	d.Part1, err = measure(func() error {
+		_, err := p.Part(1, parsed)
+		return err
+	})
+	if err != nil {
+		return dayBenchmarks{}, fmt.Errorf("part 1: %w", err)
+	}
+	d.Part2, err = measure(func() error {
+		_, err := p.Part(2, parsed)
+		return err
+	})
+	if err != nil {
+		return dayBenchmarks{}, fmt.Errorf("part 2: %w", err)
+	}
+	return d, nil
+}
+
+// formatBenchmark formats a benchmark, comparing it to an older one if given.
+func formatBenchmark(b benchmark, old *benchmark) string {
+	s := fmt.Sprintf("%v\t%d", time.Duration(b.NsPerOp), b.AllocsPerOp)
+	if old == nil || old.NsPerOp == 0 {
+		return s + "\t"
+	}
+	delta := 100 * float64(b.NsPerOp-old.NsPerOp) / float64(old.NsPerOp)
+	return s + fmt.Sprintf("\t%+.1f%%", delta)
+}
+
+// printBenchmarks writes a table of benchmark results, compared to old results if given.
+func printBenchmarks(out io.Writer, days []dayBenchmarks, old *benchReport) {
+	oldDays := make(map[int]dayBenchmarks)
+	if old != nil {
+		for _, d := range old.Days {
+			oldDays[d.Day] = d
+		}
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "day\tparse\tallocs\tΔ\tpart 1\tallocs\tΔ\tpart 2\tallocs\tΔ\t")
+	for _, d := range days {
+		var op, o1, o2 *benchmark
+		if o, ok := oldDays[d.Day]; ok {
+			op, o1, o2 = &o.Parse, &o.Part1, &o.Part2
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t\n",
+			d.Day,
+			formatBenchmark(d.Parse, op),
+			formatBenchmark(d.Part1, o1),
+			formatBenchmark(d.Part2, o2))
+	}
+	w.Flush()
+}
+
+// readBenchReport reads saved benchmark results.
+func readBenchReport(path string) (*benchReport, error) {
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r benchReport
+	if err := json.Unmarshal(text, &r); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &r, nil
+}
+
+// benchCmd benchmarks each day's solver and prints a table of timings.
+func benchCmd(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	year := fs.Int("year", 2024, "puzzle year")
+	day := fs.Int("day", 0, "puzzle day to benchmark; benchmarks every day if unset")
+	out := fs.String("out", "", "save results as JSON to this file")
+	compare := fs.String("compare", "", "compare against results saved by a previous run")
+	fs.Parse(args)
+
+	var old *benchReport
+	if *compare != "" {
+		var err error
+		old, err = readBenchReport(*compare)
+		if err != nil {
+			return fmt.Errorf("reading previous results: %w", err)
+		}
+	}
+
+	days := puzzle.Days(*year)
+	if *day != 0 {
+		days = []int{*day}
+	}
+
+	report := benchReport{Year: *year}
+	for _, day := range days {
+		p, ok := puzzle.Lookup(*year, day)
+		if !ok {
+			return fmt.Errorf("no solver for %d day %d", *year, day)
+		}
+		d, err := benchDay(p)
+		if err != nil {
+			return fmt.Errorf("day %d: %w", day, err)
+		}
+		report.Days = append(report.Days, d)
+	}
+
+	printBenchmarks(os.Stdout, report.Days, old)
+
+	if *out != "" {
+		text, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(*out, append(text, '\n'), 0o644); err != nil {
+			return fmt.Errorf("saving results: %w", err)
+		}
+	}
+	return nil
+}
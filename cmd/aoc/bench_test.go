@@ -0,0 +1,111 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"alger.au/aoc/input"
+)
+
+func TestFormatBenchmark(t *testing.T) {
+	tests := []struct {
+		name string
+		b    benchmark
+		old  *benchmark
+		want string
+	}{
+		{"no comparison", benchmark{NsPerOp: 1500, AllocsPerOp: 3}, nil, "1.5µs\t3\t"},
+		{"slower", benchmark{NsPerOp: 1500, AllocsPerOp: 3}, &benchmark{NsPerOp: 1000}, "1.5µs\t3\t+50.0%"},
+		{"faster", benchmark{NsPerOp: 750}, &benchmark{NsPerOp: 1000}, "750ns\t0\t-25.0%"},
+		{"old zero", benchmark{NsPerOp: 750}, &benchmark{}, "750ns\t0\t"},
+	}
+	for _, tt := range tests {
+		if got := formatBenchmark(tt.b, tt.old); got != tt.want {
+			t.Errorf("%s: formatBenchmark(%+v, %+v) = %q; want %q", tt.name, tt.b, tt.old, got, tt.want)
+		}
+	}
+}
+
+func TestPrintBenchmarks(t *testing.T) {
+	days := []dayBenchmarks{
+		{Day: 1, Parse: benchmark{NsPerOp: 100}, Part1: benchmark{NsPerOp: 200, AllocsPerOp: 1}, Part2: benchmark{NsPerOp: 300}},
+		{Day: 2, Parse: benchmark{NsPerOp: 100}, Part1: benchmark{NsPerOp: 100}, Part2: benchmark{NsPerOp: 100}},
+	}
+	old := &benchReport{Year: 2024, Days: []dayBenchmarks{
+		{Day: 1, Parse: benchmark{NsPerOp: 200}, Part1: benchmark{NsPerOp: 200}, Part2: benchmark{NsPerOp: 100}},
+	}}
+
+	var b strings.Builder
+	printBenchmarks(&b, days, old)
+	want := "" +
+		"  day  parse  allocs       Δ  part 1  allocs      Δ  part 2  allocs        Δ\n" +
+		"    1  100ns       0  -50.0%   200ns       1  +0.0%   300ns       0  +200.0%\n" +
+		"    2  100ns       0           100ns       0          100ns       0         \n"
+	if got := b.String(); got != want {
+		t.Errorf("printBenchmarks =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestReadBenchReport(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "bench.json")
+	if err := os.WriteFile(path, []byte(`{"year": 2024, "days": [{"day": 3, "part1": {"ns_per_op": 42}}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	r, err := readBenchReport(path)
+	if err != nil {
+		t.Fatalf("readBenchReport: %v", err)
+	}
+	if r.Year != 2024 || len(r.Days) != 1 || r.Days[0].Day != 3 || r.Days[0].Part1.NsPerOp != 42 {
+		t.Errorf("readBenchReport = %+v; want day 3 with part 1 at 42ns", r)
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readBenchReport(path); err == nil {
+		t.Errorf("readBenchReport of invalid JSON returned no error")
+	}
+}
+
+// benchOnce makes testing.Benchmark run each benchmark once for the rest of the test.
+func benchOnce(t *testing.T) {
+	t.Helper()
+	f := flag.Lookup("test.benchtime")
+	old := f.Value.String()
+	if err := f.Value.Set("1x"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { f.Value.Set(old) })
+}
+
+func TestBenchCmdCompare(t *testing.T) {
+	benchOnce(t)
+	dir := t.TempDir()
+	t.Setenv(input.DirEnv, dir)
+	if err := os.WriteFile(filepath.Join(dir, "day1.txt"), []byte("abc"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	old := filepath.Join(dir, "old.json")
+	if err := os.WriteFile(old, []byte(`{"year": 2, "days": [{"day": 1, "parse": {"ns_per_op": 1}}]}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(dir, "new.json")
+	if err := benchCmd([]string{"--year", "2", "--day", "1", "--compare", old, "--out", out}); err != nil {
+		t.Fatalf("benchCmd: %v", err)
+	}
+	r, err := readBenchReport(out)
+	if err != nil {
+		t.Fatalf("reading saved results: %v", err)
+	}
+	if r.Year != 2 || len(r.Days) != 1 || r.Days[0].Day != 1 || r.Days[0].Part1.NsPerOp == 0 {
+		t.Errorf("saved results = %+v; want timings for day 1", r)
+	}
+
+	if err := benchCmd([]string{"--year", "2", "--compare", filepath.Join(t.TempDir(), "missing.json")}); err == nil {
+		t.Errorf("benchCmd comparing against a missing file returned no error")
+	}
+}
@@ -0,0 +1,12 @@
+package main
+
+// Each day registers its solver when imported.
+import (
+	_ "alger.au/aoc/2024/day1"
+	_ "alger.au/aoc/2024/day2"
+	_ "alger.au/aoc/2024/day3"
+	_ "alger.au/aoc/2024/day4"
+	_ "alger.au/aoc/2024/day5"
+	_ "alger.au/aoc/2024/day6"
+	_ "alger.au/aoc/2024/day7"
+)
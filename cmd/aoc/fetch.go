@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"alger.au/aoc/client"
+)
+
+// fetchInput downloads a day's input (or reuses the cached copy) and saves it to path.
+func fetchInput(baseURL string, year, day int, path string) ([]byte, error) {
+	c, err := client.New(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.Input(context.Background(), year, day)
+	if err != nil {
+		return nil, fmt.Errorf("fetching input: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("saving input: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return nil, fmt.Errorf("saving input: %w", err)
+	}
+	return data, nil
+}
+
+// fetchCmd downloads a day's input into the data directory.
+func fetchCmd(args []string) error {
+	fs := flag.NewFlagSet("fetch", flag.ExitOnError)
+	year := fs.Int("year", 2024, "puzzle year")
+	day := fs.Int("day", 0, "puzzle day")
+	out := fs.String("out", "", "where to save the input (default: <year>/data/day<day>.txt)")
+	baseURL := fs.String("base-url", "", "website to fetch from (default: $AOC_BASE_URL or "+client.DefaultBaseURL+")")
+	fs.Parse(args)
+
+	if *day < 1 || *day > 25 {
+		return fmt.Errorf("invalid day: %d", *day)
+	}
+	path := *out
+	if path == "" {
+		path = fmt.Sprintf("%d/data/day%d.txt", *year, *day)
+	}
+	if _, err := fetchInput(*baseURL, *year, *day, path); err != nil {
+		return err
+	}
+	fmt.Println(path)
+	return nil
+}
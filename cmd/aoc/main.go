@@ -0,0 +1,67 @@
+// Command aoc runs Advent of Code solutions.
+//
+// Usage:
+//
+//	aoc run --year 2024 [--day 6] [--part 2] [--input path] [--format json] [--explain] [--summary]
+//	        [--cpuprofile cpu.pprof] [--memprofile mem.pprof] [--trace trace.out]
+//	        [-- solver options]
+//	aoc verify --year 2024 [--answers path]
+//	aoc bench --year 2024 [--day 6] [--out results.json] [--compare old.json]
+//	aoc fetch --year 2024 --day 8 [--out path] [--base-url url]
+//	aoc new --year 2024 --day 8 --name resonant_collinearity
+//	aoc submit --year 2024 --day 5 --part 2 [--answer N] [--base-url url]
+//	aoc parity --year 2024 [--day 7] [--python python3]
+//	aoc watch --year 2024 --day 6 [--poll]
+//	aoc profile --year 2024 --day 6 [--part 2] [--mem] [--top 15]
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+)
+
+const usage = `usage: aoc <command> [flags]
+
+commands:
+  run    run a day's solver
+  verify check every solver against the known answers
+  bench  time each day's parse and parts
+  fetch  download a day's puzzle input
+  new    generate the skeleton of a new day
+  submit submit a part's answer
+  parity compare alternate implementations with the Go solvers
+  watch  rerun a day's tests and solver when its files change
+  profile show the functions a day's solver spends the most time in
+`
+
+// commands maps subcommand names to their implementations.
+var commands = map[string]func(args []string) error{
+	"run":     runCmd,
+	"verify":  verifyCmd,
+	"bench":   benchCmd,
+	"fetch":   fetchCmd,
+	"new":     newCmd,
+	"submit":  submitCmd,
+	"parity":  parityCmd,
+	"watch":   watchCmd,
+	"profile": profileCmd,
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("aoc: ")
+
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+	if err := cmd(os.Args[2:]); err != nil {
+		log.Fatal(err)
+	}
+}
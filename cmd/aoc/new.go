@@ -0,0 +1,205 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// modulePath is the import path of this module.
+const modulePath = "alger.au/aoc"
+
+// daysFile is the file that imports every day's package, relative to the module root.
+const daysFile = "cmd/aoc/days.go"
+
+var solverTemplate = template.Must(template.New("solver").Parse(`package day{{.Day}}
+
+import (
+	"errors"
+
+	"alger.au/aoc/input"
+	"alger.au/aoc/puzzle"
+)
+
+const dataPath = "{{.Year}}/data/day{{.Day}}.txt"
+
+func init() {
+	puzzle.Register({{.Year}}, {{.Day}}, dataPath, solver{})
+}
+
+// solver solves day {{.Day}}.
+type solver struct{}
+
+// Parse splits the input into lines.
+func (solver) Parse(data []byte) ([]string, error) {
+	return input.Lines(data), nil
+}
+
+// Part1 solves part 1.
+func (solver) Part1(lines []string) (puzzle.Answer, error) {
+	return 0, errors.New("not implemented")
+}
+
+// Part2 solves part 2.
+func (solver) Part2(lines []string) (puzzle.Answer, error) {
+	return 0, errors.New("not implemented")
+}
+`))
+
+var testTemplate = template.Must(template.New("test").Parse(`package day{{.Day}}
+
+import (
+	"os"
+	"testing"
+
+	"alger.au/aoc/puzzle"
+	"alger.au/aoc/puzzle/puzzletest"
+)
+
+func TestSolver(t *testing.T) {
+	data, err := os.ReadFile("testdata/example.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines, err := solver{}.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	tests := []struct {
+		part int
+		f    func([]string) (puzzle.Answer, error)
+		want puzzle.Answer
+	}{
+		// TODO: Fill in the example answers from the puzzle statement.
+		{1, solver{}.Part1, 0},
+		{2, solver{}.Part2, 0},
+	}
+	for _, tt := range tests {
+		if tt.want == 0 {
+			t.Logf("TODO: no expected answer for part %d", tt.part)
+			continue
+		}
+		if got, err := tt.f(lines); err != nil || got != tt.want {
+			t.Errorf("Part%d = %d, %v; want %d", tt.part, got, err, tt.want)
+		}
+	}
+}
+
+func BenchmarkSolver(b *testing.B) {
+	puzzletest.Benchmark(b, solver{}, "../data/day{{.Day}}.txt")
+}
+`))
+
+// validName matches names that can be used as file names, like resonant_collinearity.
+var validName = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// scaffold generates the package for a new day under root and registers it.
+// It returns the paths of the files it wrote.
+func scaffold(root string, year, day int, name string) ([]string, error) {
+	if day < 1 || day > 25 {
+		return nil, fmt.Errorf("invalid day: %d", day)
+	}
+	if !validName.MatchString(name) {
+		return nil, fmt.Errorf("invalid name %q: use lowercase letters, digits and underscores", name)
+	}
+
+	dir := filepath.Join(root, fmt.Sprint(year), fmt.Sprintf("day%d", day))
+	if _, err := os.Stat(dir); err == nil {
+		return nil, fmt.Errorf("%s already exists", dir)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "testdata"), 0o755); err != nil {
+		return nil, err
+	}
+
+	params := struct{ Year, Day int }{year, day}
+	files := []struct {
+		path string
+		tmpl *template.Template
+	}{
+		{filepath.Join(dir, name+".go"), solverTemplate},
+		{filepath.Join(dir, name+"_test.go"), testTemplate},
+	}
+	var written []string
+	for _, f := range files {
+		var b bytes.Buffer
+		if err := f.tmpl.Execute(&b, params); err != nil {
+			return nil, err
+		}
+		src, err := format.Source(b.Bytes())
+		if err != nil {
+			return nil, fmt.Errorf("formatting %s: %w", f.path, err)
+		}
+		if err := os.WriteFile(f.path, src, 0o644); err != nil {
+			return nil, err
+		}
+		written = append(written, f.path)
+	}
+
+	example := filepath.Join(dir, "testdata", "example.txt")
+	if err := os.WriteFile(example, nil, 0o644); err != nil {
+		return nil, err
+	}
+	written = append(written, example)
+
+	days := filepath.Join(root, daysFile)
+	pkg := fmt.Sprintf("%s/%d/day%d", modulePath, year, day)
+	if err := addImport(days, pkg); err != nil {
+		return nil, fmt.Errorf("registering %s: %w", pkg, err)
+	}
+	written = append(written, days)
+	return written, nil
+}
+
+// addImport adds a blank import of pkg to the import block of a Go file.
+func addImport(path, pkg string) error {
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(string(text), "\n")
+	start := slices.Index(lines, "import (")
+	if start < 0 {
+		return errors.New("no import block")
+	}
+	end := start + slices.Index(lines[start:], ")")
+
+	spec := fmt.Sprintf("\t_ %q", pkg)
+	if slices.Contains(lines[start:end], spec) {
+		return nil
+	}
+	// Formatting sorts the imports.
+	lines = slices.Insert(lines, end, spec)
+	src, err := format.Source([]byte(strings.Join(lines, "\n")))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, src, 0o644)
+}
+
+// newCmd generates the skeleton of a new day.
+func newCmd(args []string) error {
+	fs := flag.NewFlagSet("new", flag.ExitOnError)
+	year := fs.Int("year", 2024, "puzzle year")
+	day := fs.Int("day", 0, "puzzle day")
+	name := fs.String("name", "", "puzzle name, like resonant_collinearity")
+	fs.Parse(args)
+
+	written, err := scaffold(".", *year, *day, *name)
+	if err != nil {
+		return err
+	}
+	for _, path := range written {
+		fmt.Println(path)
+	}
+	return nil
+}
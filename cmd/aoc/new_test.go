@@ -0,0 +1,56 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestScaffold(t *testing.T) {
+	root := t.TempDir()
+	days := filepath.Join(root, daysFile)
+	if err := os.MkdirAll(filepath.Dir(days), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	orig := "package main\n\nimport (\n\t_ \"alger.au/aoc/2024/day1\"\n\t_ \"alger.au/aoc/2024/day9\"\n)\n"
+	if err := os.WriteFile(days, []byte(orig), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := scaffold(root, 2024, 10, "hoof_it"); err != nil {
+		t.Fatalf("scaffold returned error: %v", err)
+	}
+
+	for _, f := range []string{"hoof_it.go", "hoof_it_test.go", "testdata/example.txt"} {
+		if _, err := os.Stat(filepath.Join(root, "2024", "day10", f)); err != nil {
+			t.Errorf("scaffold did not write %s: %v", f, err)
+		}
+	}
+	src, err := os.ReadFile(filepath.Join(root, "2024", "day10", "hoof_it.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(src), `puzzle.Register(2024, 10, dataPath, solver{})`) {
+		t.Errorf("generated solver is not registered:\n%s", src)
+	}
+
+	got, err := os.ReadFile(days)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "package main\n\nimport (\n\t_ \"alger.au/aoc/2024/day1\"\n\t_ \"alger.au/aoc/2024/day10\"\n\t_ \"alger.au/aoc/2024/day9\"\n)\n"
+	if string(got) != want {
+		t.Errorf("days.go =\n%s\nwant\n%s", got, want)
+	}
+
+	if _, err := scaffold(root, 2024, 10, "hoof_it"); err == nil {
+		t.Errorf("scaffolding an existing day returned no error")
+	}
+}
+
+func TestScaffoldInvalidName(t *testing.T) {
+	if _, err := scaffold(t.TempDir(), 2024, 8, "Resonant Collinearity"); err == nil {
+		t.Errorf("scaffold with an invalid name returned no error")
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"alger.au/aoc/input"
+	"alger.au/aoc/puzzle"
+)
+
+// interpreters maps the extensions of alternate implementations to the
+// commands that run them. Each is run with the input path as its argument
+// and should print answers like the Go runner, one "part: answer" per line.
+var interpreters = map[string][]string{
+	".py": {"python3"},
+}
+
+// findAlternates finds alternate implementations in a day's directory.
+func findAlternates(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var alts []string
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		if _, ok := interpreters[filepath.Ext(e.Name())]; ok {
+			alts = append(alts, filepath.Join(dir, e.Name()))
+		}
+	}
+	return alts, nil
+}
+
+// parseAnswers parses "part: answer" lines printed by a solution.
+func parseAnswers(out []byte) (map[int]string, error) {
+	answers := make(map[int]string)
+	s := bufio.NewScanner(bytes.NewReader(out))
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" {
+			continue
+		}
+		p, a, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("unexpected output: %s", line)
+		}
+		part, err := strconv.Atoi(strings.TrimSpace(p))
+		if err != nil {
+			return nil, fmt.Errorf("unexpected output: %s", line)
+		}
+		answers[part] = strings.TrimSpace(a)
+	}
+	return answers, s.Err()
+}
+
+// runAlternate runs an alternate implementation on an input file.
+func runAlternate(path, inputPath string) (map[int]string, error) {
+	interp := interpreters[filepath.Ext(path)]
+	cmd := exec.Command(interp[0], slices.Concat(interp[1:], []string{filepath.Base(path), inputPath})...)
+	// Run from the solution's directory so that its default paths work.
+	cmd.Dir = filepath.Dir(path)
+	cmd.Stderr = os.Stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("running %s: %w", path, err)
+	}
+	return parseAnswers(out)
+}
+
+// parityCmd compares the answers of alternate implementations with the Go solvers.
+func parityCmd(args []string) error {
+	fs := flag.NewFlagSet("parity", flag.ExitOnError)
+	year := fs.Int("year", 2024, "puzzle year")
+	day := fs.Int("day", 0, "puzzle day; checks every day if unset")
+	python := fs.String("python", "python3", "Python interpreter")
+	fs.Parse(args)
+
+	interpreters[".py"] = []string{*python}
+
+	days := puzzle.Days(*year)
+	if *day != 0 {
+		days = []int{*day}
+	}
+
+	failures := 0
+	for _, day := range days {
+		p, ok := puzzle.Lookup(*year, day)
+		if !ok {
+			return fmt.Errorf("no solver for %d day %d", *year, day)
+		}
+		alts, err := findAlternates(filepath.Join(fmt.Sprint(*year), fmt.Sprintf("day%d", day)))
+		if err != nil {
+			return err
+		}
+		if len(alts) == 0 {
+			continue
+		}
+
+		// Solving first fetches the data file if it is missing.
+		want := make(map[int]string)
+		for _, r := range solveParts(p, []int{1, 2}, "") {
+			want[r.Part] = r.text()
+		}
+
+		inputPath, err := filepath.Abs(input.Path(p.DataPath))
+		if err != nil {
+			return err
+		}
+		for _, alt := range alts {
+			fmt.Printf("day %d %s\n", day, filepath.Base(alt))
+			got, err := runAlternate(alt, inputPath)
+			if err != nil {
+				failures++
+				fmt.Printf("  FAIL %v\n", err)
+				continue
+			}
+			for part := 1; part <= 2; part++ {
+				if got[part] != want[part] {
+					failures++
+					fmt.Printf("  DIFF part %d: go %s, %s %s\n", part, want[part], filepath.Base(alt), got[part])
+					continue
+				}
+				fmt.Printf("  ok   part %d: %s\n", part, got[part])
+			}
+		}
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("%d divergences", failures)
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestParseAnswers(t *testing.T) {
+	got, err := parseAnswers([]byte("1: 3749\n2: 11387\n\n"))
+	if err != nil {
+		t.Fatalf("parseAnswers returned error: %v", err)
+	}
+	want := map[int]string{1: "3749", 2: "11387"}
+	if !maps.Equal(got, want) {
+		t.Errorf("parseAnswers = %v; want %v", got, want)
+	}
+
+	if _, err := parseAnswers([]byte("Traceback (most recent call last):\n")); err == nil {
+		t.Errorf("parseAnswers of unexpected output returned no error")
+	}
+}
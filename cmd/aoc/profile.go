@@ -0,0 +1,156 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+
+	"alger.au/aoc/puzzle"
+)
+
+// profiling holds the paths to write profiles to. Empty paths are skipped.
+type profiling struct {
+	cpu, mem, trace string
+}
+
+// register adds flags for each profile to fs.
+func (pr *profiling) register(fs *flag.FlagSet) {
+	fs.StringVar(&pr.cpu, "cpuprofile", "", "write a CPU profile to this file")
+	fs.StringVar(&pr.mem, "memprofile", "", "write an allocation profile to this file")
+	fs.StringVar(&pr.trace, "trace", "", "write an execution trace to this file")
+}
+
+// enabled returns whether any profile was requested.
+func (pr profiling) enabled() bool {
+	return pr.cpu != "" || pr.mem != "" || pr.trace != ""
+}
+
+// start starts profiling. The returned function stops profiling and writes the profiles.
+func (pr profiling) start() (func() error, error) {
+	var files []*os.File
+	closeAll := func() error {
+		var errs []error
+		for _, f := range files {
+			errs = append(errs, f.Close())
+		}
+		return errors.Join(errs...)
+	}
+
+	if pr.cpu != "" {
+		f, err := os.Create(pr.cpu)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+		if err := pprof.StartCPUProfile(f); err != nil {
+			closeAll()
+			return nil, fmt.Errorf("starting CPU profile: %w", err)
+		}
+	}
+	if pr.trace != "" {
+		f, err := os.Create(pr.trace)
+		if err == nil {
+			files = append(files, f)
+			err = trace.Start(f)
+		}
+		if err != nil {
+			if pr.cpu != "" {
+				pprof.StopCPUProfile()
+			}
+			closeAll()
+			return nil, fmt.Errorf("starting trace: %w", err)
+		}
+	}
+
+	return func() error {
+		if pr.cpu != "" {
+			pprof.StopCPUProfile()
+		}
+		if pr.trace != "" {
+			trace.Stop()
+		}
+		err := closeAll()
+		if pr.mem != "" {
+			err = errors.Join(err, writeMemProfile(pr.mem))
+		}
+		return err
+	}, nil
+}
+
+// writeMemProfile writes a profile of every allocation made so far.
+func writeMemProfile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	// Collect garbage so the profile is up to date.
+	runtime.GC()
+	if err := pprof.Lookup("allocs").WriteTo(f, 0); err != nil {
+		f.Close()
+		return fmt.Errorf("writing memory profile: %w", err)
+	}
+	return f.Close()
+}
+
+// profileCmd profiles a day's solver and prints the functions it spends the most time in.
+func profileCmd(args []string) error {
+	fs := flag.NewFlagSet("profile", flag.ExitOnError)
+	year := fs.Int("year", 2024, "puzzle year")
+	day := fs.Int("day", 0, "puzzle day")
+	part := fs.Int("part", 0, "puzzle part to profile (1 or 2); profiles both if unset")
+	inputPath := fs.String("input", "", "path to the puzzle input, or - for stdin (default: the day's data file, in $AOC_INPUT_DIR if set)")
+	mem := fs.Bool("mem", false, "show the functions that allocate the most memory instead")
+	top := fs.Int("top", 15, "number of functions to show")
+	fs.Parse(args)
+
+	p, ok := puzzle.Lookup(*year, *day)
+	if !ok {
+		return fmt.Errorf("no solver for %d day %d", *year, *day)
+	}
+	parts := []int{1, 2}
+	if *part != 0 {
+		parts = []int{*part}
+	}
+
+	dir, err := os.MkdirTemp("", "aoc-profile")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "cpu.pprof")
+	pr := profiling{cpu: path}
+	pprofArgs := []string{"tool", "pprof", "-top", fmt.Sprintf("-nodecount=%d", *top)}
+	if *mem {
+		path = filepath.Join(dir, "mem.pprof")
+		pr = profiling{mem: path}
+		pprofArgs = append(pprofArgs, "-sample_index=alloc_space")
+	}
+
+	stop, err := pr.start()
+	if err != nil {
+		return err
+	}
+	results := solveParts(p, parts, *inputPath)
+	if err := stop(); err != nil {
+		return err
+	}
+
+	for _, r := range results {
+		if r.Error != "" {
+			return fmt.Errorf("day %d part %d: %s", r.Day, r.Part, r.Error)
+		}
+		fmt.Printf("%d: %d\n", r.Part, r.Answer)
+	}
+
+	cmd := exec.Command("go", append(pprofArgs, path)...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProfiling(t *testing.T) {
+	dir := t.TempDir()
+	pr := profiling{
+		cpu:   filepath.Join(dir, "cpu.pprof"),
+		mem:   filepath.Join(dir, "mem.pprof"),
+		trace: filepath.Join(dir, "trace.out"),
+	}
+	stop, err := pr.start()
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if err := stop(); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+
+	for _, path := range []string{pr.cpu, pr.mem, pr.trace} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Errorf("profile not written: %v", err)
+		} else if info.Size() == 0 {
+			t.Errorf("%s is empty", path)
+		}
+	}
+}
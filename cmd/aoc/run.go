@@ -0,0 +1,309 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"alger.au/aoc/input"
+	"alger.au/aoc/puzzle"
+	"github.com/schollz/progressbar/v3"
+)
+
+// result is the outcome of solving one part of a puzzle.
+type result struct {
+	Year       int           `json:"year"`
+	Day        int           `json:"day"`
+	Part       int           `json:"part"`
+	Answer     puzzle.Answer `json:"answer"`
+	DurationNS int64         `json:"duration_ns"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// text formats the answer, or the error if solving failed.
+func (r result) text() string {
+	if r.Error != "" {
+		return "error: " + r.Error
+	}
+	return fmt.Sprint(r.Answer)
+}
+
+// solveParts solves the given parts of a puzzle, reading input from path
+// (or the puzzle's data file if path is empty).
+// Failures are recorded in the results rather than returned.
+func solveParts(p puzzle.Puzzle, parts []int, path string) []result {
+	results := make([]result, 0, len(parts))
+	parsed, err := readAndParse(p, path)
+	for _, part := range parts {
+		r := result{Year: p.Year, Day: p.Day, Part: part}
+		if err != nil {
+			r.Error = err.Error()
+			results = append(results, r)
+			continue
+		}
+
+		start := time.Now()
+		answer, err := solvePart(func() (puzzle.Answer, error) {
+			return p.Part(part, parsed)
+		})
+		r.DurationNS = time.Since(start).Nanoseconds()
+		if err != nil {
+			r.Error = err.Error()
+		} else {
+			r.Answer = answer
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+// streamParts solves the given parts of a puzzle as its input is read from
+// path (or the puzzle's data file if path is empty), reading it again for each part.
+// Failures are recorded in the results rather than returned.
+func streamParts(p puzzle.Puzzle, parts []int, path string) []result {
+	results := make([]result, 0, len(parts))
+	var err error
+	if path == "" {
+		path, err = dataFile(p)
+	}
+	for _, part := range parts {
+		r := result{Year: p.Year, Day: p.Day, Part: part}
+		if err != nil {
+			r.Error = err.Error()
+			results = append(results, r)
+			continue
+		}
+
+		start := time.Now()
+		answer, err := streamPart(p, part, path)
+		r.DurationNS = time.Since(start).Nanoseconds()
+		if err != nil {
+			r.Error = err.Error()
+		} else {
+			r.Answer = answer
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+// streamPart solves one part of a puzzle as its input is read from path.
+func streamPart(p puzzle.Puzzle, part int, path string) (puzzle.Answer, error) {
+	in, err := input.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("reading data: %w", err)
+	}
+	defer in.Close()
+	return solvePart(func() (puzzle.Answer, error) {
+		return p.Stream(part, in)
+	})
+}
+
+// solvePart solves one part of a puzzle with f, turning a panic in the solver into an error.
+func solvePart(f func() (puzzle.Answer, error)) (answer puzzle.Answer, err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = fmt.Errorf("panic: %v", v)
+		}
+	}()
+	return f()
+}
+
+// runCmd runs a day's solver and prints the answers.
+func runCmd(args []string) (err error) {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	year := fs.Int("year", 2024, "puzzle year")
+	day := fs.Int("day", 0, "puzzle day; runs every day if unset")
+	part := fs.Int("part", 0, "puzzle part to run (1 or 2); runs both if unset")
+	inputPath := fs.String("input", "", "path to the puzzle input, or - for stdin (default: the day's data file, in $AOC_INPUT_DIR if set)")
+	format := fs.String("format", "text", "output format: text, or json for one record per line")
+	explain := fs.Bool("explain", false, "explain the day's answers instead of solving it, if its solver can")
+	summary := fs.Bool("summary", false, "summarize the day's input instead of solving it, if its solver can")
+	stream := fs.Bool("stream", false, "solve the day as its input is read instead of loading it all, if its solver can")
+	var prof profiling
+	prof.register(fs)
+	fs.Parse(args)
+
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("invalid format: %s", *format)
+	}
+
+	days := puzzle.Days(*year)
+	if *day != 0 {
+		days = []int{*day}
+	} else if *inputPath != "" {
+		return errors.New("--input needs --day")
+	} else if prof.enabled() {
+		return errors.New("profiling needs --day")
+	} else if *explain || *summary || *stream {
+		return errors.New("--explain, --summary and --stream need --day")
+	} else if fs.NArg() > 0 {
+		return errors.New("solver options need --day")
+	}
+
+	if *day != 0 {
+		p, ok := puzzle.Lookup(*year, *day)
+		if !ok {
+			return fmt.Errorf("no solver for %d day %d", *year, *day)
+		}
+		if err := p.Configure(fs.Args()); errors.Is(err, flag.ErrHelp) {
+			// The solver has printed its options.
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if *explain {
+			return explainDay(p, *inputPath)
+		}
+		if *summary {
+			return summarizeDay(p, *inputPath, *format)
+		}
+	}
+	if *format == "text" {
+		for _, day := range days {
+			if p, ok := puzzle.Lookup(*year, day); ok {
+				p.SetProgress(progressBar())
+			}
+		}
+	}
+
+	parts := []int{1, 2}
+	if *part != 0 {
+		parts = []int{*part}
+	}
+	solve := solveParts
+	if *stream {
+		if *inputPath == input.Stdin && len(parts) > 1 {
+			return errors.New("streaming from stdin needs --part")
+		}
+		solve = streamParts
+	}
+
+	stop, err := prof.start()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if serr := stop(); serr != nil {
+			err = errors.Join(err, fmt.Errorf("writing profiles: %w", serr))
+		}
+	}()
+
+	enc := json.NewEncoder(os.Stdout)
+	failures := 0
+	for _, day := range days {
+		p, ok := puzzle.Lookup(*year, day)
+		if !ok {
+			return fmt.Errorf("no solver for %d day %d", *year, day)
+		}
+		if *format == "text" && len(days) > 1 {
+			fmt.Printf("day %d\n", day)
+		}
+
+		for _, r := range solve(p, parts, *inputPath) {
+			if r.Error != "" {
+				failures++
+			}
+			switch {
+			case *format == "json":
+				if err := enc.Encode(r); err != nil {
+					return err
+				}
+			case r.Error != "":
+				log.Printf("day %d part %d: %s", r.Day, r.Part, r.Error)
+			default:
+				fmt.Printf("%d: %d\n", r.Part, r.Answer)
+			}
+		}
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("%d parts failed", failures)
+	}
+	return nil
+}
+
+// progressBar returns a function that reports progress with a bar on stderr.
+func progressBar() func(done, total int) {
+	var bar *progressbar.ProgressBar
+	return func(done, total int) {
+		if bar == nil {
+			bar = progressbar.Default(int64(total))
+		}
+		bar.Set(done)
+	}
+}
+
+// explainDay prints the explanation of a puzzle's answers.
+func explainDay(p puzzle.Puzzle, path string) error {
+	parsed, err := readAndParse(p, path)
+	if err != nil {
+		return err
+	}
+	if err := p.Explain(os.Stdout, parsed); err != nil {
+		return fmt.Errorf("day %d: %w", p.Day, err)
+	}
+	return nil
+}
+
+// summarizeDay prints a summary of a puzzle's input as text or JSON.
+func summarizeDay(p puzzle.Puzzle, path, format string) error {
+	parsed, err := readAndParse(p, path)
+	if err != nil {
+		return err
+	}
+	summary, err := p.Summarize(parsed)
+	if err != nil {
+		return fmt.Errorf("day %d: %w", p.Day, err)
+	}
+	if format == "json" {
+		return json.NewEncoder(os.Stdout).Encode(summary)
+	}
+	fmt.Print(summary)
+	return nil
+}
+
+// readInput reads a puzzle's input from path, or from its data file if path is empty.
+// A missing data file is fetched from the website.
+func readInput(p puzzle.Puzzle, path string) ([]byte, error) {
+	if path == "" {
+		path = input.Path(p.DataPath)
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			return fetchInput("", p.Year, p.Day, path)
+		}
+	}
+	data, err := input.Read(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading data: %w", err)
+	}
+	return data, nil
+}
+
+// dataFile finds the path to a puzzle's data file, fetching it if it is missing.
+func dataFile(p puzzle.Puzzle) (string, error) {
+	path := input.Path(p.DataPath)
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		if _, err := fetchInput("", p.Year, p.Day, path); err != nil {
+			return "", err
+		}
+	}
+	return path, nil
+}
+
+// readAndParse reads a puzzle's input and parses it.
+// The input is read from path, or from the puzzle's data file if path is empty.
+func readAndParse(p puzzle.Puzzle, path string) (any, error) {
+	data, err := readInput(p, path)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := p.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing: %w", err)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"alger.au/aoc/puzzle"
+)
+
+// lengthSolver is a stand-in solver whose answer is the length of the input.
+type lengthSolver struct{}
+
+func (lengthSolver) Parse(data []byte) (int, error) {
+	if len(data) == 0 {
+		return 0, errors.New("empty input")
+	}
+	return len(data), nil
+}
+
+func (lengthSolver) Part1(n int) (puzzle.Answer, error) {
+	return puzzle.Answer(n), nil
+}
+
+func (lengthSolver) Part2(n int) (puzzle.Answer, error) {
+	return 0, errors.New("unsolved")
+}
+
+// Stream counts the bytes of input for part 1, like Parse and Part1.
+func (lengthSolver) Stream(part int, r io.Reader) (puzzle.Answer, error) {
+	if part == 2 {
+		return 0, errors.New("unsolved")
+	}
+	n, err := io.Copy(io.Discard, r)
+	return puzzle.Answer(n), err
+}
+
+// panicSolver is a stand-in solver whose first part panics.
+type panicSolver struct{ lengthSolver }
+
+func (panicSolver) Part1(n int) (puzzle.Answer, error) {
+	panic("boom")
+}
+
+func init() {
+	puzzle.Register(1, 1, "", lengthSolver{})
+	puzzle.Register(1, 2, "", panicSolver{})
+}
+
+func TestSolveParts(t *testing.T) {
+	p, _ := puzzle.Lookup(1, 1)
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte("abc"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	rs := solveParts(p, []int{1, 2}, path)
+	if len(rs) != 2 {
+		t.Fatalf("solveParts returned %d results; want 2", len(rs))
+	}
+	if rs[0].Answer != 3 || rs[0].Error != "" {
+		t.Errorf("part 1 = %+v; want answer 3", rs[0])
+	}
+	if rs[1].Error != "unsolved" {
+		t.Errorf("part 2 = %+v; want error unsolved", rs[1])
+	}
+
+	empty := filepath.Join(t.TempDir(), "empty.txt")
+	if err := os.WriteFile(empty, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range solveParts(p, []int{1, 2}, empty) {
+		if r.Error == "" {
+			t.Errorf("part %d of unparseable input = %+v; want an error", r.Part, r)
+		}
+	}
+}
+
+func TestSolvePartsPanic(t *testing.T) {
+	p, _ := puzzle.Lookup(1, 2)
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte("abc"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	rs := solveParts(p, []int{1, 2}, path)
+	if len(rs) != 2 {
+		t.Fatalf("solveParts returned %d results; want 2", len(rs))
+	}
+	if rs[0].Error != "panic: boom" {
+		t.Errorf("part 1 = %+v; want error panic: boom", rs[0])
+	}
+	if rs[1].Error != "unsolved" {
+		t.Errorf("part 2 = %+v; want error unsolved", rs[1])
+	}
+}
+
+func TestRunCmdWritesProfilesOnFailure(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "input.txt")
+	if err := os.WriteFile(path, []byte("abc"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cpu := filepath.Join(dir, "cpu.pprof")
+	mem := filepath.Join(dir, "missing", "mem.pprof")
+
+	err := runCmd([]string{"--year", "1", "--day", "1", "--input", path, "--format", "json", "--cpuprofile", cpu, "--memprofile", mem})
+	if err == nil || !strings.Contains(err.Error(), "1 parts failed") || !strings.Contains(err.Error(), "writing profiles") {
+		t.Errorf("runCmd = %v; want both the failed part and the profile error", err)
+	}
+	if info, err := os.Stat(cpu); err != nil || info.Size() == 0 {
+		t.Errorf("CPU profile not written: %v", err)
+	}
+}
+
+func TestRunCmdSolverHelp(t *testing.T) {
+	if err := runCmd([]string{"--year", "2024", "--day", "2", "--", "-h"}); err != nil {
+		t.Errorf("runCmd with -- -h = %v; want nil", err)
+	}
+}
+
+func TestStreamParts(t *testing.T) {
+	p, _ := puzzle.Lookup(1, 1)
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte("abcd"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	rs := streamParts(p, []int{1, 2}, path)
+	if len(rs) != 2 {
+		t.Fatalf("streamParts returned %d results; want 2", len(rs))
+	}
+	if rs[0].Answer != 4 || rs[0].Error != "" {
+		t.Errorf("part 1 = %+v; want answer 4", rs[0])
+	}
+	if rs[1].Error != "unsolved" {
+		t.Errorf("part 2 = %+v; want error unsolved", rs[1])
+	}
+
+	for _, r := range streamParts(p, []int{1}, filepath.Join(t.TempDir(), "missing.txt")) {
+		if r.Error == "" {
+			t.Errorf("part %d of a missing file = %+v; want an error", r.Part, r)
+		}
+	}
+
+	// doubleSolver, from verify_test.go, cannot stream.
+	p, _ = puzzle.Lookup(2, 1)
+	for _, r := range streamParts(p, []int{1}, path) {
+		if r.Error != puzzle.ErrNoStream.Error() {
+			t.Errorf("part %d without a Streamer = %+v; want error %v", r.Part, r, puzzle.ErrNoStream)
+		}
+	}
+}
+
+func TestRunCmdStreamStdin(t *testing.T) {
+	if err := runCmd([]string{"--year", "1", "--day", "1", "--stream", "--input", "-"}); err == nil {
+		t.Errorf("runCmd streaming both parts from stdin returned no error")
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"alger.au/aoc/client"
+	"alger.au/aoc/puzzle"
+)
+
+// submitCmd submits a part's answer, refusing answers that earlier guesses rule out.
+func submitCmd(args []string) error {
+	fs := flag.NewFlagSet("submit", flag.ExitOnError)
+	year := fs.Int("year", 2024, "puzzle year")
+	day := fs.Int("day", 0, "puzzle day")
+	part := fs.Int("part", 0, "puzzle part (1 or 2)")
+	answer := fs.String("answer", "", "answer to submit (default: run the day's solver)")
+	inputPath := fs.String("input", "", "path to the puzzle input, or - for stdin (default: the day's data file)")
+	baseURL := fs.String("base-url", "", "website to submit to (default: $AOC_BASE_URL or "+client.DefaultBaseURL+")")
+	historyPath := fs.String("history", "", "path to the submission history (default: history.json in the config directory)")
+	fs.Parse(args)
+
+	if *part != 1 && *part != 2 {
+		return fmt.Errorf("invalid part: %d", *part)
+	}
+
+	if *answer == "" {
+		p, ok := puzzle.Lookup(*year, *day)
+		if !ok {
+			return fmt.Errorf("no solver for %d day %d", *year, *day)
+		}
+		r := solveParts(p, []int{*part}, *inputPath)[0]
+		if r.Error != "" {
+			return errors.New(r.Error)
+		}
+		*answer = fmt.Sprint(r.Answer)
+	}
+
+	path := *historyPath
+	if path == "" {
+		var err error
+		path, err = client.HistoryPath()
+		if err != nil {
+			return err
+		}
+	}
+	history, err := client.ReadHistory(path)
+	if err != nil {
+		return fmt.Errorf("reading history: %w", err)
+	}
+	if err := history.Check(*year, *day, *part, *answer); err != nil {
+		return fmt.Errorf("not submitting: %w", err)
+	}
+
+	c, err := client.New(*baseURL)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("submitting %s for %d day %d part %d\n", *answer, *year, *day, *part)
+	v, err := c.Submit(context.Background(), *year, *day, *part, *answer)
+	if err != nil {
+		return err
+	}
+	fmt.Println(v.Message)
+
+	// Only record answers that were actually checked.
+	if v.Outcome == client.Correct || v.Outcome.IsWrong() {
+		history.Add(client.Guess{
+			Year:    *year,
+			Day:     *day,
+			Part:    *part,
+			Answer:  *answer,
+			Outcome: v.Outcome,
+			Time:    time.Now(),
+		})
+		if err := history.Write(path); err != nil {
+			return fmt.Errorf("writing history: %w", err)
+		}
+	}
+	if v.Outcome != client.Correct {
+		return fmt.Errorf("answer %s", v.Outcome)
+	}
+	return nil
+}
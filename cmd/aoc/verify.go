@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"alger.au/aoc/puzzle"
+)
+
+// answers are the known correct answers for a year, keyed by day and then part.
+type answers map[int]map[int]puzzle.Answer
+
+// readAnswers reads known answers from a JSON file.
+func readAnswers(path string) (answers, error) {
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var a answers
+	if err := json.Unmarshal(text, &a); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return a, nil
+}
+
+// lookup finds the known answer for a day and part.
+func (a answers) lookup(day, part int) (puzzle.Answer, bool) {
+	answer, ok := a[day][part]
+	return answer, ok
+}
+
+// verifyCmd runs every solver for a year and compares the results to the known answers.
+func verifyCmd(args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	year := fs.Int("year", 2024, "puzzle year")
+	answersPath := fs.String("answers", "", "path to the known answers (default: <year>/answers.json)")
+	fs.Parse(args)
+
+	path := *answersPath
+	if path == "" {
+		path = fmt.Sprintf("%d/answers.json", *year)
+	}
+	known, err := readAnswers(path)
+	if err != nil {
+		return fmt.Errorf("reading answers: %w", err)
+	}
+
+	return verify(os.Stdout, *year, known)
+}
+
+// verify runs every solver for a year, writes how each part compares to the
+// known answers to w, and fails if any are wrong.
+func verify(w io.Writer, year int, known answers) error {
+	failures := 0
+	for _, day := range puzzle.Days(year) {
+		p, _ := puzzle.Lookup(year, day)
+		parsed, err := readAndParse(p, "")
+		if err != nil {
+			failures++
+			fmt.Fprintf(w, "FAIL day %d: %v\n", day, err)
+			continue
+		}
+
+		for part := 1; part <= 2; part++ {
+			want, ok := known.lookup(day, part)
+			if !ok {
+				fmt.Fprintf(w, "?    day %d part %d: no known answer\n", day, part)
+				continue
+			}
+
+			got, err := solvePart(func() (puzzle.Answer, error) {
+				return p.Part(part, parsed)
+			})
+			if err != nil {
+				failures++
+				fmt.Fprintf(w, "FAIL day %d part %d: %v\n", day, part, err)
+				continue
+			}
+			if got != want {
+				failures++
+				fmt.Fprintf(w, "FAIL day %d part %d\n", day, part)
+				fmt.Fprintf(w, "\t- %d\n", want)
+				fmt.Fprintf(w, "\t+ %d\n", got)
+				continue
+			}
+			fmt.Fprintf(w, "ok   day %d part %d\n", day, part)
+		}
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("%d wrong answers", failures)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"alger.au/aoc/input"
+	"alger.au/aoc/puzzle"
+)
+
+// doubleSolver is a stand-in solver whose answers are the length of the input and twice that.
+type doubleSolver struct{}
+
+func (doubleSolver) Parse(data []byte) (int, error) {
+	return len(data), nil
+}
+
+func (doubleSolver) Part1(n int) (puzzle.Answer, error) {
+	return puzzle.Answer(n), nil
+}
+
+func (doubleSolver) Part2(n int) (puzzle.Answer, error) {
+	return puzzle.Answer(2 * n), nil
+}
+
+func init() {
+	puzzle.Register(2, 1, "2/data/day1.txt", doubleSolver{})
+	puzzle.Register(3, 1, "3/data/day1.txt", panicSolver{})
+}
+
+// writeVerifyFiles writes the input for doubleSolver (and panicSolver) and an
+// answers file, and returns the path to the answers.
+func writeVerifyFiles(t *testing.T, answersJSON string) string {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv(input.DirEnv, dir)
+	if err := os.WriteFile(filepath.Join(dir, "day1.txt"), []byte("abc"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "answers.json")
+	if err := os.WriteFile(path, []byte(answersJSON), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name    string
+		year    int
+		answers string
+		want    string
+		wantErr string
+	}{
+		{
+			"correct",
+			2,
+			`{"1": {"1": 3, "2": 6}}`,
+			"ok   day 1 part 1\nok   day 1 part 2\n",
+			"",
+		},
+		{
+			"wrong",
+			2,
+			`{"1": {"1": 3, "2": 7}}`,
+			"ok   day 1 part 1\nFAIL day 1 part 2\n\t- 7\n\t+ 6\n",
+			"1 wrong answers",
+		},
+		{
+			"unknown",
+			2,
+			`{"1": {"1": 3}}`,
+			"ok   day 1 part 1\n?    day 1 part 2: no known answer\n",
+			"",
+		},
+		{
+			"panic",
+			3,
+			`{"1": {"1": 3, "2": 6}}`,
+			"FAIL day 1 part 1: panic: boom\nFAIL day 1 part 2: unsolved\n",
+			"2 wrong answers",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			known, err := readAnswers(writeVerifyFiles(t, tt.answers))
+			if err != nil {
+				t.Fatalf("readAnswers: %v", err)
+			}
+			var b strings.Builder
+			err = verify(&b, tt.year, known)
+			if got := b.String(); got != tt.want {
+				t.Errorf("verify printed %q; want %q", got, tt.want)
+			}
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("verify returned error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("verify returned %v; want %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyCmdFails(t *testing.T) {
+	path := writeVerifyFiles(t, `{"1": {"1": 4, "2": 6}}`)
+	if err := verifyCmd([]string{"--year", "2", "--answers", path}); err == nil {
+		t.Errorf("verifyCmd with a wrong answer returned no error")
+	}
+}
+
+func TestReadAnswers(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "answers.json")
+	if err := os.WriteFile(path, []byte(`{"1": {"2": 42}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	a, err := readAnswers(path)
+	if err != nil {
+		t.Fatalf("readAnswers: %v", err)
+	}
+	if got, ok := a.lookup(1, 2); !ok || got != 42 {
+		t.Errorf("lookup(1, 2) = %d, %t; want 42, true", got, ok)
+	}
+	if _, ok := a.lookup(1, 1); ok {
+		t.Errorf("lookup(1, 1) found an answer; want none")
+	}
+
+	if err := os.WriteFile(path, []byte(`{"1": `), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readAnswers(path); err == nil {
+		t.Errorf("readAnswers of invalid JSON returned no error")
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"alger.au/aoc/input"
+	"alger.au/aoc/puzzle"
+)
+
+// settle is how long to wait after a change for an editor to finish saving.
+const settle = 100 * time.Millisecond
+
+// watchPaths returns the paths to watch for changes to a day:
+// its package, its example inputs and its data file.
+func watchPaths(p puzzle.Puzzle) []string {
+	dir := filepath.Join(fmt.Sprint(p.Year), fmt.Sprintf("day%d", p.Day))
+	return []string{dir, filepath.Join(dir, "testdata"), input.Path(p.DataPath)}
+}
+
+// snapshot records the modification times of paths and of the files in any
+// directories among them. Missing paths are skipped.
+func snapshot(paths []string) map[string]time.Time {
+	s := make(map[string]time.Time)
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		s[path] = info.ModTime()
+		if !info.IsDir() {
+			continue
+		}
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if info, err := e.Info(); err == nil {
+				s[filepath.Join(path, e.Name())] = info.ModTime()
+			}
+		}
+	}
+	return s
+}
+
+// poll waits until something in paths changes, checking every interval.
+func poll(paths []string, interval time.Duration) {
+	old := snapshot(paths)
+	for {
+		time.Sleep(interval)
+		if !maps.EqualFunc(old, snapshot(paths), time.Time.Equal) {
+			return
+		}
+	}
+}
+
+// rebuild builds a day's package, printing any compiler errors.
+func rebuild(dir string) bool {
+	out, err := exec.Command("go", "build", "./"+dir).CombinedOutput()
+	if err != nil {
+		os.Stdout.Write(out)
+		fmt.Println("build FAIL")
+		return false
+	}
+	return true
+}
+
+// runTests runs a day's tests, which solve its examples.
+// The output is only printed if they fail.
+func runTests(dir string) {
+	out, err := exec.Command("go", "test", "./"+dir).CombinedOutput()
+	if err != nil {
+		os.Stdout.Write(out)
+		fmt.Println("tests FAIL")
+		return
+	}
+	fmt.Println("tests ok")
+}
+
+// solveLatest solves a day on its real input with a freshly built runner and
+// returns the answers by part.
+func solveLatest(year, day int) (map[int]string, error) {
+	cmd := exec.Command("go", "run", "./cmd/aoc", "run",
+		"--year", fmt.Sprint(year), "--day", fmt.Sprint(day), "--format", "json")
+	cmd.Stderr = os.Stderr
+	// The runner fails if any part fails, but still reports every part.
+	out, runErr := cmd.Output()
+
+	answers := make(map[int]string)
+	dec := json.NewDecoder(bytes.NewReader(out))
+	for {
+		var r result
+		if err := dec.Decode(&r); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("reading answers: %w", err)
+		}
+		answers[r.Part] = r.text()
+	}
+	if len(answers) == 0 && runErr != nil {
+		return nil, runErr
+	}
+	return answers, nil
+}
+
+// printAnswers prints answers, marking those that changed since the previous run.
+func printAnswers(w io.Writer, answers, prev map[int]string) {
+	for _, part := range slices.Sorted(maps.Keys(answers)) {
+		a := answers[part]
+		old, ok := prev[part]
+		switch {
+		case !ok:
+			fmt.Fprintf(w, "%d: %s\n", part, a)
+		case old == a:
+			fmt.Fprintf(w, "%d: %s (unchanged)\n", part, a)
+		default:
+			fmt.Fprintf(w, "%d: %s (was %s)\n", part, a, old)
+		}
+	}
+}
+
+// watchCmd reruns a day's tests and solver whenever its files change.
+func watchCmd(args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	year := fs.Int("year", 2024, "puzzle year")
+	day := fs.Int("day", 0, "puzzle day")
+	usePoll := fs.Bool("poll", false, "poll for changes instead of using inotify")
+	interval := fs.Duration("interval", 500*time.Millisecond, "how often to poll for changes")
+	fs.Parse(args)
+
+	p, ok := puzzle.Lookup(*year, *day)
+	if !ok {
+		return fmt.Errorf("no solver for %d day %d", *year, *day)
+	}
+	paths := watchPaths(p)
+	dir := paths[0]
+
+	var prev map[int]string
+	for {
+		fmt.Printf("--- %s\n", time.Now().Format(time.TimeOnly))
+		if rebuild(dir) {
+			runTests(dir)
+			answers, err := solveLatest(*year, *day)
+			if err != nil {
+				log.Printf("day %d: %v", *day, err)
+			} else {
+				printAnswers(os.Stdout, answers, prev)
+				prev = answers
+			}
+		}
+
+		if !*usePoll {
+			err := notify(paths)
+			if err == nil {
+				time.Sleep(settle)
+				continue
+			}
+			if !errors.Is(err, errors.ErrUnsupported) {
+				log.Printf("watching with inotify: %v; polling instead", err)
+			}
+			*usePoll = true
+		}
+		poll(paths, *interval)
+		time.Sleep(settle)
+	}
+}
@@ -0,0 +1,39 @@
+//go:build linux
+
+package main
+
+import (
+	"errors"
+	"syscall"
+)
+
+// notify waits until something in paths changes, using inotify.
+// Missing paths are skipped.
+func notify(paths []string) error {
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC)
+	if err != nil {
+		return err
+	}
+	defer syscall.Close(fd)
+
+	const mask = syscall.IN_MODIFY | syscall.IN_CLOSE_WRITE |
+		syscall.IN_CREATE | syscall.IN_DELETE | syscall.IN_MOVED_FROM | syscall.IN_MOVED_TO |
+		syscall.IN_DELETE_SELF | syscall.IN_MOVE_SELF
+	watched := 0
+	for _, path := range paths {
+		if _, err := syscall.InotifyAddWatch(fd, path, mask); err == nil {
+			watched++
+		}
+	}
+	if watched == 0 {
+		return errors.New("nothing to watch")
+	}
+
+	buf := make([]byte, 4096)
+	for {
+		_, err := syscall.Read(fd, buf)
+		if err != syscall.EINTR {
+			return err
+		}
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !linux
+
+package main
+
+import "errors"
+
+// notify is only implemented with inotify, so other systems poll instead.
+func notify(paths []string) error {
+	return errors.ErrUnsupported
+}
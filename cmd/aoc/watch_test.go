@@ -0,0 +1,47 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPrintAnswers(t *testing.T) {
+	var b bytes.Buffer
+	printAnswers(&b, map[int]string{2: "6", 1: "41"}, map[int]string{1: "41", 2: "5"})
+	want := "1: 41 (unchanged)\n2: 6 (was 5)\n"
+	if got := b.String(); got != want {
+		t.Errorf("printAnswers = %q; want %q", got, want)
+	}
+}
+
+func TestPoll(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "day6.go")
+	if err := os.WriteFile(path, []byte("package day6\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		poll([]string{dir}, time.Millisecond)
+		close(done)
+	}()
+	// Keep touching the file in case poll has not taken its first snapshot yet.
+	timeout := time.After(5 * time.Second)
+	for i := 1; ; i++ {
+		later := time.Now().Add(time.Duration(i) * time.Second)
+		if err := os.Chtimes(path, later, later); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case <-done:
+			return
+		case <-timeout:
+			t.Fatal("poll did not notice a change")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
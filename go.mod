@@ -1,4 +1,4 @@
-module alger.au/aoc/2024/day6
+module alger.au/aoc
 
 go 1.23.4
 
@@ -0,0 +1,199 @@
+// Package grid provides rectangular 2D grids of bytes, such as puzzle maps.
+package grid
+
+import (
+	"bytes"
+	"fmt"
+	"iter"
+
+	"alger.au/aoc/input"
+)
+
+// Point is a location in a grid, or an offset between locations.
+// X increases to the right and Y increases downwards.
+type Point struct {
+	X int
+	Y int
+}
+
+// Add adds an offset to a point.
+func (p Point) Add(q Point) Point {
+	return Point{p.X + q.X, p.Y + q.Y}
+}
+
+// Scale multiplies an offset by k.
+func (p Point) Scale(k int) Point {
+	return Point{p.X * k, p.Y * k}
+}
+
+// RotateRight rotates an offset 90° clockwise.
+func (p Point) RotateRight() Point {
+	return Point{-p.Y, p.X}
+}
+
+// RotateLeft rotates an offset 90° anticlockwise.
+func (p Point) RotateLeft() Point {
+	return Point{p.Y, -p.X}
+}
+
+// Unit offsets in each direction.
+var (
+	Up        = Point{0, -1}
+	UpRight   = Point{1, -1}
+	Right     = Point{1, 0}
+	DownRight = Point{1, 1}
+	Down      = Point{0, 1}
+	DownLeft  = Point{-1, 1}
+	Left      = Point{-1, 0}
+	UpLeft    = Point{-1, -1}
+)
+
+// Orthogonal lists the four orthogonal directions, clockwise from Up.
+var Orthogonal = []Point{Up, Right, Down, Left}
+
+// Directions lists all eight directions, clockwise from Up.
+var Directions = []Point{Up, UpRight, Right, DownRight, Down, DownLeft, Left, UpLeft}
+
+// Grid is a rectangular grid of bytes.
+type Grid struct {
+	Width  int
+	Height int
+	cells  []byte
+}
+
+// New makes a grid filled with a single value.
+func New(width, height int, fill byte) *Grid {
+	return &Grid{
+		Width:  width,
+		Height: height,
+		cells:  bytes.Repeat([]byte{fill}, width*height),
+	}
+}
+
+// Parse parses a grid with one row per line.
+// Lines may end in CRLF, and trailing blank lines are ignored.
+// All rows must be the same length.
+func Parse(data []byte) (*Grid, error) {
+	rows := input.Lines(data)
+	if len(rows) == 0 {
+		return &Grid{}, nil
+	}
+
+	g := &Grid{
+		Width:  len(rows[0]),
+		Height: len(rows),
+		cells:  make([]byte, 0, len(rows[0])*len(rows)),
+	}
+	for y, row := range rows {
+		if len(row) != g.Width {
+			return nil, &input.Error{
+				Line: y + 1,
+				Err:  fmt.Errorf("expected %d columns; got %d", g.Width, len(row)),
+			}
+		}
+		g.cells = append(g.cells, row...)
+	}
+	return g, nil
+}
+
+// In determines whether a point is inside the grid.
+func (g *Grid) In(p Point) bool {
+	return p.X >= 0 && p.X < g.Width && p.Y >= 0 && p.Y < g.Height
+}
+
+// At gets the value at a point.
+// It returns false if the point is outside the grid.
+func (g *Grid) At(p Point) (byte, bool) {
+	if !g.In(p) {
+		return 0, false
+	}
+	return g.cells[p.Y*g.Width+p.X], true
+}
+
+// Set sets the value at a point.
+// It returns false, leaving the grid unchanged, if the point is outside the grid.
+func (g *Grid) Set(p Point, v byte) bool {
+	if !g.In(p) {
+		return false
+	}
+	g.cells[p.Y*g.Width+p.X] = v
+	return true
+}
+
+// Row gets a row of the grid. The row shares memory with the grid.
+func (g *Grid) Row(y int) []byte {
+	return g.cells[y*g.Width : (y+1)*g.Width]
+}
+
+// All iterates over every point in the grid in reading order, with its value.
+func (g *Grid) All() iter.Seq2[Point, byte] {
+	return func(yield func(Point, byte) bool) {
+		for i, v := range g.cells {
+			if !yield(Point{i % g.Width, i / g.Width}, v) {
+				return
+			}
+		}
+	}
+}
+
+// Find finds the first point in reading order with the given value.
+func (g *Grid) Find(v byte) (Point, bool) {
+	i := bytes.IndexByte(g.cells, v)
+	if i < 0 {
+		return Point{}, false
+	}
+	return Point{i % g.Width, i / g.Width}, true
+}
+
+// FindAll finds every point with the given value, in reading order.
+func (g *Grid) FindAll(v byte) []Point {
+	var ps []Point
+	for p, w := range g.All() {
+		if w == v {
+			ps = append(ps, p)
+		}
+	}
+	return ps
+}
+
+// Neighbours iterates over the neighbours of a point in the given directions,
+// skipping any outside the grid.
+func (g *Grid) Neighbours(p Point, dirs []Point) iter.Seq2[Point, byte] {
+	return func(yield func(Point, byte) bool) {
+		for _, d := range dirs {
+			q := p.Add(d)
+			v, ok := g.At(q)
+			if !ok {
+				continue
+			}
+			if !yield(q, v) {
+				return
+			}
+		}
+	}
+}
+
+// Count counts how many times a value appears in the grid.
+func (g *Grid) Count(v byte) int {
+	return bytes.Count(g.cells, []byte{v})
+}
+
+// Clone deep-copies a grid.
+func (g *Grid) Clone() *Grid {
+	return &Grid{
+		Width:  g.Width,
+		Height: g.Height,
+		cells:  bytes.Clone(g.cells),
+	}
+}
+
+// String renders the grid with one row per line.
+func (g *Grid) String() string {
+	var b bytes.Buffer
+	b.Grow((g.Width + 1) * g.Height)
+	for y := range g.Height {
+		b.Write(g.Row(y))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
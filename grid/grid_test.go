@@ -0,0 +1,133 @@
+package grid
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		wantString string
+		wantWidth  int
+		wantHeight int
+	}{
+		{"simple", "ab\ncd", "ab\ncd\n", 2, 2},
+		{"trailing newline", "ab\ncd\n", "ab\ncd\n", 2, 2},
+		{"trailing blank lines", "ab\ncd\n\n\n", "ab\ncd\n", 2, 2},
+		{"crlf", "ab\r\ncd\r\n", "ab\ncd\n", 2, 2},
+		{"empty", "", "", 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := Parse([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.data, err)
+			}
+			if g.Width != tt.wantWidth || g.Height != tt.wantHeight {
+				t.Errorf("Parse(%q) is %dx%d; want %dx%d", tt.data, g.Width, g.Height, tt.wantWidth, tt.wantHeight)
+			}
+			if got := g.String(); got != tt.wantString {
+				t.Errorf("Parse(%q).String() = %q; want %q", tt.data, got, tt.wantString)
+			}
+		})
+	}
+}
+
+func TestParseRagged(t *testing.T) {
+	if _, err := Parse([]byte("abc\nde\n")); err == nil {
+		t.Errorf("Parse of ragged rows returned no error")
+	}
+}
+
+func TestAtSet(t *testing.T) {
+	g := New(3, 2, '.')
+	if !g.Set(Point{2, 1}, '#') {
+		t.Fatalf("Set(2, 1) = false; want true")
+	}
+	if g.Set(Point{3, 1}, '#') {
+		t.Errorf("Set(3, 1) = true; want false")
+	}
+	tests := []struct {
+		p      Point
+		want   byte
+		wantOK bool
+	}{
+		{Point{0, 0}, '.', true},
+		{Point{2, 1}, '#', true},
+		{Point{-1, 0}, 0, false},
+		{Point{0, 2}, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := g.At(tt.p)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("At(%v) = %q, %t; want %q, %t", tt.p, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	g, err := Parse([]byte(".#.\n#..\n..#\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, ok := g.Find('#'); !ok || p != (Point{1, 0}) {
+		t.Errorf("Find('#') = %v, %t; want {1 0}, true", p, ok)
+	}
+	if _, ok := g.Find('X'); ok {
+		t.Errorf("Find('X') found a point")
+	}
+	want := []Point{{1, 0}, {0, 1}, {2, 2}}
+	if got := g.FindAll('#'); !slices.Equal(got, want) {
+		t.Errorf("FindAll('#') = %v; want %v", got, want)
+	}
+	if got := g.Count('.'); got != 6 {
+		t.Errorf("Count('.') = %d; want 6", got)
+	}
+}
+
+func TestRotate(t *testing.T) {
+	for i, d := range Orthogonal {
+		if got, want := d.RotateRight(), Orthogonal[(i+1)%4]; got != want {
+			t.Errorf("%v.RotateRight() = %v; want %v", d, got, want)
+		}
+		if got, want := d.RotateLeft(), Orthogonal[(i+3)%4]; got != want {
+			t.Errorf("%v.RotateLeft() = %v; want %v", d, got, want)
+		}
+	}
+}
+
+func TestNeighbours(t *testing.T) {
+	g, err := Parse([]byte("abc\ndef\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		p    Point
+		dirs []Point
+		want string
+	}{
+		{Point{0, 0}, Orthogonal, "bd"},
+		{Point{1, 1}, Orthogonal, "bfd"},
+		{Point{1, 0}, Directions, "cfeda"},
+	}
+	for _, tt := range tests {
+		var got []byte
+		for _, v := range g.Neighbours(tt.p, tt.dirs) {
+			got = append(got, v)
+		}
+		if string(got) != tt.want {
+			t.Errorf("Neighbours(%v) = %q; want %q", tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestClone(t *testing.T) {
+	g := New(2, 2, '.')
+	c := g.Clone()
+	c.Set(Point{0, 0}, '#')
+	if v, _ := g.At(Point{0, 0}); v != '.' {
+		t.Errorf("modifying a clone modified the original")
+	}
+}
@@ -0,0 +1,179 @@
+// Package input provides helpers for parsing puzzle input.
+package input
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Error is an error at a position in the input.
+type Error struct {
+	// Line is the 1-based line number, or 0 if unknown.
+	Line int
+	// Column is the 1-based byte offset in the line, or 0 if unknown.
+	Column int
+	Err    error
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Line > 0 && e.Column > 0:
+		return fmt.Sprintf("line %d, column %d: %v", e.Line, e.Column, e.Err)
+	case e.Line > 0:
+		return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+	case e.Column > 0:
+		return fmt.Sprintf("column %d: %v", e.Column, e.Err)
+	default:
+		return e.Err.Error()
+	}
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// AtLine attaches a line number to an error, keeping any column it already has.
+func AtLine(line int, err error) error {
+	var e *Error
+	if errors.As(err, &e) && e.Line == 0 {
+		return &Error{Line: line, Column: e.Column, Err: e.Err}
+	}
+	return &Error{Line: line, Err: err}
+}
+
+// Lines splits data into lines.
+// Lines may end in CRLF, and trailing blank lines are ignored.
+func Lines(data []byte) []string {
+	lines := strings.Split(string(data), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	// Strip trailing empty lines.
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// Section is a block of consecutive non-blank lines.
+type Section struct {
+	// Start is the 1-based line number of the first line in the section.
+	Start int
+	Lines []string
+}
+
+// Sections splits data into sections separated by blank lines.
+func Sections(data []byte) []Section {
+	var sections []Section
+	var current *Section
+	for i, line := range Lines(data) {
+		if strings.TrimSpace(line) == "" {
+			current = nil
+			continue
+		}
+		if current == nil {
+			sections = append(sections, Section{Start: i + 1})
+			current = &sections[len(sections)-1]
+		}
+		current.Lines = append(current.Lines, line)
+	}
+	return sections
+}
+
+// Map parses each line with f.
+// Errors are annotated with the line number.
+func Map[T any](lines []string, f func(line string) (T, error)) ([]T, error) {
+	return mapFrom(1, lines, f)
+}
+
+// MapSection parses each line of a section with f.
+// Errors are annotated with the line number in the original input.
+func MapSection[T any](s Section, f func(line string) (T, error)) ([]T, error) {
+	return mapFrom(s.Start, s.Lines, f)
+}
+
+// MapNonBlank parses each line with f, skipping blank lines.
+// Errors are annotated with the line number.
+func MapNonBlank[T any](lines []string, f func(line string) (T, error)) ([]T, error) {
+	out := make([]T, 0, len(lines))
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		v, err := f(line)
+		if err != nil {
+			return nil, AtLine(i+1, err)
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+// mapFrom parses each line with f, numbering lines from start.
+func mapFrom[T any](start int, lines []string, f func(line string) (T, error)) ([]T, error) {
+	out := make([]T, 0, len(lines))
+	for i, line := range lines {
+		v, err := f(line)
+		if err != nil {
+			return nil, AtLine(start+i, err)
+		}
+		out = append(out, v)
+	}
+	return out, nil
+}
+
+// field is a whitespace-separated field and its 1-based column.
+type field struct {
+	column int
+	text   string
+}
+
+// fields splits s on any run of whitespace, keeping track of columns.
+func fields(s string) []field {
+	var fs []field
+	start := -1
+	for i, r := range s {
+		if unicode.IsSpace(r) {
+			if start >= 0 {
+				fs = append(fs, field{start + 1, s[start:i]})
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		fs = append(fs, field{start + 1, s[start:]})
+	}
+	return fs
+}
+
+// Fields splits a line on any run of whitespace.
+func Fields(s string) []string {
+	fs := fields(s)
+	out := make([]string, len(fs))
+	for i, f := range fs {
+		out[i] = f.text
+	}
+	return out
+}
+
+// Ints parses a line of integers separated by any run of whitespace.
+// Errors are annotated with the column of the invalid integer.
+func Ints(s string) ([]int, error) {
+	fs := fields(s)
+	out := make([]int, len(fs))
+	for i, f := range fs {
+		v, err := strconv.Atoi(f.text)
+		if err != nil {
+			return nil, &Error{Column: f.column, Err: err}
+		}
+		out[i] = v
+	}
+	return out, nil
+}
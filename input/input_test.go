@@ -0,0 +1,109 @@
+package input
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []string
+	}{
+		{"simple", "a\nb", []string{"a", "b"}},
+		{"trailing newline", "a\nb\n", []string{"a", "b"}},
+		{"trailing blank lines", "a\nb\n\n \n", []string{"a", "b"}},
+		{"crlf", "a\r\nb\r\n", []string{"a", "b"}},
+		{"inner blank line", "a\n\nb", []string{"a", "", "b"}},
+		{"empty", "", []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Lines([]byte(tt.data)); !slices.Equal(got, tt.want) {
+				t.Errorf("Lines(%q) = %q; want %q", tt.data, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSections(t *testing.T) {
+	got := Sections([]byte("a\nb\n\n\nc\r\n\r\nd\n"))
+	want := []Section{
+		{1, []string{"a", "b"}},
+		{5, []string{"c"}},
+		{7, []string{"d"}},
+	}
+	if !slices.EqualFunc(got, want, func(a, b Section) bool {
+		return a.Start == b.Start && slices.Equal(a.Lines, b.Lines)
+	}) {
+		t.Errorf("Sections = %q; want %q", got, want)
+	}
+}
+
+func TestInts(t *testing.T) {
+	tests := []struct {
+		s    string
+		want []int
+	}{
+		{"1 2 3", []int{1, 2, 3}},
+		{"3   4", []int{3, 4}},
+		{"\t-5\t 6 ", []int{-5, 6}},
+		{"", []int{}},
+	}
+	for _, tt := range tests {
+		got, err := Ints(tt.s)
+		if err != nil {
+			t.Fatalf("Ints(%q) returned error: %v", tt.s, err)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Ints(%q) = %v; want %v", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestFields(t *testing.T) {
+	want := []string{"a", "bc", "d"}
+	if got := Fields(" a \tbc  d"); !slices.Equal(got, want) {
+		t.Errorf("Fields = %q; want %q", got, want)
+	}
+}
+
+func TestErrorPosition(t *testing.T) {
+	_, err := Map([]string{"1 2", "3 x4"}, Ints)
+	var e *Error
+	if !errors.As(err, &e) {
+		t.Fatalf("Map returned %v; want an *Error", err)
+	}
+	if e.Line != 2 || e.Column != 3 {
+		t.Errorf("error at line %d, column %d; want line 2, column 3", e.Line, e.Column)
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("error %v does not wrap strconv.ErrSyntax", err)
+	}
+
+	s := Section{Start: 10, Lines: []string{"1", "oops"}}
+	_, err = MapSection(s, strconv.Atoi)
+	if !errors.As(err, &e) || e.Line != 11 {
+		t.Errorf("MapSection returned %v; want an error on line 11", err)
+	}
+}
+
+func TestMapNonBlank(t *testing.T) {
+	got, err := MapNonBlank([]string{"1 2", "", "  \t", "3"}, Ints)
+	if err != nil {
+		t.Fatalf("MapNonBlank returned error: %v", err)
+	}
+	if want := [][]int{{1, 2}, {3}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("MapNonBlank = %v; want %v", got, want)
+	}
+
+	_, err = MapNonBlank([]string{"1", "", "x"}, Ints)
+	var e *Error
+	if !errors.As(err, &e) || e.Line != 3 {
+		t.Errorf("MapNonBlank returned %v; want an error on line 3", err)
+	}
+}
@@ -0,0 +1,40 @@
+package input
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// DirEnv is the environment variable that overrides the directory puzzle inputs are read from.
+const DirEnv = "AOC_INPUT_DIR"
+
+// Stdin is the path that means the input should be read from stdin.
+const Stdin = "-"
+
+// Path resolves the path to a puzzle's input.
+// If $AOC_INPUT_DIR is set, the input is the file in that directory with
+// the same name as dataPath; otherwise it is dataPath itself.
+func Path(dataPath string) string {
+	if dir := os.Getenv(DirEnv); dir != "" {
+		return filepath.Join(dir, filepath.Base(dataPath))
+	}
+	return dataPath
+}
+
+// Read reads puzzle input from a file, or from stdin if path is "-".
+func Read(path string) ([]byte, error) {
+	if path == Stdin {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
+
+// Open opens puzzle input to be read as it is needed, from a file or from
+// stdin if path is "-". Closing stdin this way leaves it open.
+func Open(path string) (io.ReadCloser, error) {
+	if path == Stdin {
+		return io.NopCloser(os.Stdin), nil
+	}
+	return os.Open(path)
+}
@@ -0,0 +1,41 @@
+package input
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPath(t *testing.T) {
+	t.Setenv(DirEnv, "")
+	if got := Path("2024/data/day1.txt"); got != "2024/data/day1.txt" {
+		t.Errorf("Path without %s = %q; want the data path", DirEnv, got)
+	}
+
+	dir := t.TempDir()
+	t.Setenv(DirEnv, dir)
+	want := filepath.Join(dir, "day1.txt")
+	if got := Path("2024/data/day1.txt"); got != want {
+		t.Errorf("Path with %s = %q; want %q", DirEnv, got, want)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte("1 2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open(%q) returned error: %v", path, err)
+	}
+	defer f.Close()
+	if data, err := io.ReadAll(f); err != nil || string(data) != "1 2\n" {
+		t.Errorf("reading %q = %q, %v; want %q", path, data, err, "1 2\n")
+	}
+
+	if _, err := Open(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Errorf("Open of a missing file returned no error")
+	}
+}
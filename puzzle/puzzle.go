@@ -0,0 +1,228 @@
+// Package puzzle provides a registry of Advent of Code puzzle solvers.
+package puzzle
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// Answer is the answer to one part of a puzzle.
+type Answer int
+
+// Solver solves a puzzle whose input parses into a T.
+// Part1 and Part2 must not modify their input, so that it can be shared between parts.
+type Solver[T any] interface {
+	Parse(data []byte) (T, error)
+	Part1(input T) (Answer, error)
+	Part2(input T) (Answer, error)
+}
+
+// Explainer is implemented by solvers that can explain how they reach their answers.
+type Explainer[T any] interface {
+	// Explain writes a human-readable explanation of the parsed input to w.
+	Explain(w io.Writer, input T) error
+}
+
+// ErrNoExplanation is returned when explaining a puzzle whose solver is not an Explainer.
+var ErrNoExplanation = errors.New("solver cannot explain its answers")
+
+// Summarizer is implemented by solvers that can summarize their input.
+type Summarizer[T any] interface {
+	// Summarize returns statistics about the parsed input, which should
+	// encode as JSON and format as text with fmt.
+	Summarize(input T) (any, error)
+}
+
+// ErrNoSummary is returned when summarizing a puzzle whose solver is not a Summarizer.
+var ErrNoSummary = errors.New("solver cannot summarize its input")
+
+// Progresser is implemented by solvers that can report the progress of slow parts.
+type Progresser interface {
+	// SetProgress sets a function to call as a part completes done of total
+	// steps, or nil to report nothing.
+	SetProgress(f func(done, total int))
+}
+
+// Streamer is implemented by solvers that can solve parts as their input is
+// read, without holding all of it in memory.
+type Streamer interface {
+	// Stream solves the given part (1 or 2) from the input read from r.
+	Stream(part int, r io.Reader) (Answer, error)
+}
+
+// ErrNoStream is returned when streaming a puzzle whose solver is not a Streamer.
+var ErrNoStream = errors.New("solver cannot stream its input")
+
+// Configurer is implemented by solvers that take options.
+type Configurer interface {
+	// Configure sets the solver's options from command-line arguments.
+	Configure(args []string) error
+}
+
+// Puzzle is a registered solver for a single day.
+type Puzzle struct {
+	Year int
+	Day  int
+	// DataPath is the default path to the puzzle input.
+	DataPath string
+
+	parse func(data []byte) (any, error)
+	part1 func(input any) (Answer, error)
+	part2 func(input any) (Answer, error)
+	// explain is nil if the solver is not an Explainer.
+	explain func(w io.Writer, input any) error
+	// summarize is nil if the solver is not a Summarizer.
+	summarize func(input any) (any, error)
+	// stream is nil if the solver is not a Streamer.
+	stream func(part int, r io.Reader) (Answer, error)
+	// configure is nil if the solver is not a Configurer.
+	configure func(args []string) error
+	// setProgress is nil if the solver is not a Progresser.
+	setProgress func(f func(done, total int))
+}
+
+// Parse parses the puzzle input.
+func (p Puzzle) Parse(data []byte) (any, error) {
+	return p.parse(data)
+}
+
+// Part solves the given part (1 or 2) of the puzzle from parsed input.
+func (p Puzzle) Part(part int, input any) (Answer, error) {
+	switch part {
+	case 1:
+		return p.part1(input)
+	case 2:
+		return p.part2(input)
+	default:
+		return 0, fmt.Errorf("invalid part: %d", part)
+	}
+}
+
+// Explain writes an explanation of the parsed input to w.
+// It returns ErrNoExplanation if the solver cannot explain its answers.
+func (p Puzzle) Explain(w io.Writer, input any) error {
+	if p.explain == nil {
+		return ErrNoExplanation
+	}
+	return p.explain(w, input)
+}
+
+// Summarize returns statistics about the parsed input.
+// It returns ErrNoSummary if the solver cannot summarize its input.
+func (p Puzzle) Summarize(input any) (any, error) {
+	if p.summarize == nil {
+		return nil, ErrNoSummary
+	}
+	return p.summarize(input)
+}
+
+// Stream solves the given part (1 or 2) of the puzzle from input read from r.
+// It returns ErrNoStream if the solver cannot stream its input.
+func (p Puzzle) Stream(part int, r io.Reader) (Answer, error) {
+	if p.stream == nil {
+		return 0, ErrNoStream
+	}
+	if part != 1 && part != 2 {
+		return 0, fmt.Errorf("invalid part: %d", part)
+	}
+	return p.stream(part, r)
+}
+
+// Configure sets the solver's options from command-line arguments.
+// It fails if there are arguments and the solver takes no options.
+func (p Puzzle) Configure(args []string) error {
+	if p.configure == nil {
+		if len(args) > 0 {
+			return fmt.Errorf("%d day %d takes no options", p.Year, p.Day)
+		}
+		return nil
+	}
+	return p.configure(args)
+}
+
+// SetProgress sets a function to report the progress of slow parts, if the solver can.
+func (p Puzzle) SetProgress(f func(done, total int)) {
+	if p.setProgress != nil {
+		p.setProgress(f)
+	}
+}
+
+// Solve parses the puzzle input and solves the given part (1 or 2).
+func (p Puzzle) Solve(part int, data []byte) (Answer, error) {
+	input, err := p.Parse(data)
+	if err != nil {
+		return 0, fmt.Errorf("parsing: %w", err)
+	}
+	return p.Part(part, input)
+}
+
+type key struct {
+	year int
+	day  int
+}
+
+var registry = make(map[key]Puzzle)
+
+// Register registers a solver for a day.
+// Solvers may also implement Explainer, Summarizer, Streamer, Configurer and Progresser.
+// It is intended to be called from the init function of each day's package.
+func Register[T any](year, day int, dataPath string, s Solver[T]) {
+	k := key{year, day}
+	if _, ok := registry[k]; ok {
+		panic(fmt.Sprintf("puzzle: %d day %d registered twice", year, day))
+	}
+	p := Puzzle{
+		Year:     year,
+		Day:      day,
+		DataPath: dataPath,
+		parse: func(data []byte) (any, error) {
+			return s.Parse(data)
+		},
+		part1: func(input any) (Answer, error) {
+			return s.Part1(input.(T))
+		},
+		part2: func(input any) (Answer, error) {
+			return s.Part2(input.(T))
+		},
+	}
+	if e, ok := s.(Explainer[T]); ok {
+		p.explain = func(w io.Writer, input any) error {
+			return e.Explain(w, input.(T))
+		}
+	}
+	if sm, ok := s.(Summarizer[T]); ok {
+		p.summarize = func(input any) (any, error) {
+			return sm.Summarize(input.(T))
+		}
+	}
+	if st, ok := s.(Streamer); ok {
+		p.stream = st.Stream
+	}
+	if c, ok := s.(Configurer); ok {
+		p.configure = c.Configure
+	}
+	if pr, ok := s.(Progresser); ok {
+		p.setProgress = pr.SetProgress
+	}
+	registry[k] = p
+}
+
+// Lookup finds the solver registered for a day.
+func Lookup(year, day int) (Puzzle, bool) {
+	p, ok := registry[key{year, day}]
+	return p, ok
+}
+
+// Days lists the registered days of a year in order.
+func Days(year int) []int {
+	var days []int
+	for k := range registry {
+		if k.year == year {
+			days = append(days, k.day)
+		}
+	}
+	slices.Sort(days)
+	return days
+}
@@ -0,0 +1,48 @@
+// Package puzzletest provides helpers for testing and benchmarking puzzle solvers.
+package puzzletest
+
+import (
+	"os"
+	"testing"
+
+	"alger.au/aoc/input"
+	"alger.au/aoc/puzzle"
+)
+
+// Benchmark benchmarks parsing and both parts of a solver on the input at path,
+// or in $AOC_INPUT_DIR if set. It skips if the input is not available.
+func Benchmark[T any](b *testing.B, s puzzle.Solver[T], path string) {
+	data, err := os.ReadFile(input.Path(path))
+	if err != nil {
+		b.Skipf("reading data: %v", err)
+	}
+	parsed, err := s.Parse(data)
+	if err != nil {
+		b.Fatalf("Parse: %v", err)
+	}
+
+	b.Run("Parse", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			if _, err := s.Parse(data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Part1", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			if _, err := s.Part1(parsed); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("Part2", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			if _, err := s.Part2(parsed); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}